@@ -2,21 +2,41 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"gopkg.in/yaml.v2"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"regexp"
+	"syscall"
+	"time"
 	"user_service/config"
 	"user_service/internal/db"
+	"user_service/internal/events"
+	"user_service/internal/gateway"
+	"user_service/internal/healthcheck"
+	"user_service/internal/interceptor"
+	"user_service/internal/notify"
+	"user_service/internal/openapi"
+	"user_service/internal/repository"
+	"user_service/internal/repository/cassandra"
+	"user_service/internal/repository/memory"
 	"user_service/internal/service"
+	"user_service/internal/webhook"
 	"user_service/protogen/user"
 )
 
+// defaultShutdownTimeout is used when the configuration sets no shutdown
+// timeout.
+const defaultShutdownTimeout = 30 * time.Second
+
 func main() {
 
 	err := godotenv.Load("../conf/config.env")
@@ -28,48 +48,221 @@ func main() {
 
 	yamlData, err := os.ReadFile(filePath)
 	if err != nil {
-		log.Fatalf("Error reading YAML file:", err)
+		log.Fatalf("Error reading YAML file: %v", err)
 		return
 	}
 
 	var cfg config.Config
 	if err := yaml.Unmarshal(yamlData, &cfg); err != nil {
-		log.Fatalf("Error unmarshaling YAML:", err)
+		log.Fatalf("Error unmarshaling YAML: %v", err)
 		return
 	}
 
-	cassandraSvc := *db.NewCassandraDetailsSvc(&cfg)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(&cfg, os.Args[2:])
+		return
+	}
+
+	var (
+		repo          repository.UserRepository
+		outbox        repository.Outbox
+		idempotency   repository.IdempotencyStore
+		blockEvents   repository.BlockEventStore
+		audit         repository.AuditStore
+		verifications repository.VerificationStore
+		webhooks      repository.WebhookStore
+		probe         healthcheck.Probe
+		closeStorage  = func() {}
+	)
+	switch cfg.Storage.Driver {
+	case "memory":
+		log.Println("Using in-memory user storage")
+		users := memory.NewUserRepository()
+		repo, outbox = users, users
+		idempotency = memory.NewIdempotencyStore()
+		blockEvents = memory.NewBlockEventStore()
+		audit = memory.NewAuditStore()
+		verifications = memory.NewVerificationStore()
+		webhooks = memory.NewWebhookStore()
+	case "", "cassandra":
+		cassandraSvc := *db.NewCassandraDetailsSvc(&cfg)
+
+		session := cassandraSvc.ConnectCassandra()
+		closeStorage = session.Close
 
-	session := cassandraSvc.ConnectCassandra()
-	defer session.Close()
+		users := cassandra.NewUserRepository(session)
+		repo, outbox = users, users
+		idempotency = cassandra.NewIdempotencyStore(session)
+		blockEvents = cassandra.NewBlockEventStore(session)
+		audit = cassandra.NewAuditStore(session)
+		verifications = cassandra.NewVerificationStore(session)
+		webhooks = cassandra.NewWebhookStore(session, cfg.Webhooks.Retention)
+		probe = func(ctx context.Context) error {
+			return db.Ping(ctx, session)
+		}
+	default:
+		log.Fatalf("Unknown storage driver: %q", cfg.Storage.Driver)
+	}
+
+	var notifier notify.Notifier
+	switch cfg.Verification.Notifier {
+	case "":
+		log.Println("WARNING: no verification notifier configured, UpdateContact and contact verification are disabled")
+	case "dev-log":
+		log.Println("WARNING: verification codes are written to the log, never use the dev-log notifier in production")
+		notifier = notify.LogNotifier{}
+	case "file":
+		notifier = notify.NewFileNotifier(cfg.Verification.NotifierFile)
+	default:
+		log.Fatalf("Unknown notifier: %q", cfg.Verification.Notifier)
+	}
+
+	feed := events.NewBroker(cfg.Events.HistorySize)
+	dispatcher := webhook.NewDispatcher(webhooks, webhook.NewClient(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks),
+		cfg.Webhooks.MaxAttempts, cfg.Webhooks.InitialBackoff, cfg.Webhooks.MaxBackoff, cfg.Webhooks.Concurrency)
+	publisher := events.Fanout{feed, dispatcher}
+	switch cfg.Events.Publisher {
+	case "", "inprocess":
+	case "file":
+		publisher = append(publisher, events.NewFilePublisher(cfg.Events.File))
+	default:
+		log.Fatalf("Unknown event publisher: %q", cfg.Events.Publisher)
+	}
 
 	// Initialize the gRPC service
-	userService := service.NewUserServiceServer(session)
+	serviceOpts := []service.Option{
+		service.WithIdempotency(idempotency, cfg.Idempotency.TTL),
+		service.WithBlockHistory(blockEvents),
+		service.WithAudit(audit),
+		service.WithEventPublisher(outbox, publisher),
+		service.WithChangeFeed(feed),
+		service.WithWebhooks(webhooks, cfg.Webhooks.AllowPrivateNetworks),
+	}
+	if notifier != nil {
+		serviceOpts = append(serviceOpts,
+			service.WithContactVerification(verifications, notifier, cfg.Verification.CodeTTL, cfg.Verification.MaxAttempts, cfg.Verification.ResendCooldown))
+	}
+	userService := service.NewUserServiceServer(repo, serviceOpts...)
+	// Background workers run until the servers have stopped, so that the
+	// events of the last requests are still relayed.
+	workCtx, stopWork := context.WithCancel(context.Background())
+	go userService.RunBlockSweeper(workCtx, cfg.Blocks.SweepInterval)
+	go userService.RunOutboxRelay(workCtx, cfg.Events.RelayInterval, cfg.Events.BatchSize)
+	go dispatcher.Run(workCtx, cfg.Webhooks.DispatchInterval, cfg.Webhooks.BatchSize)
+
+	checker := healthcheck.NewChecker(probe, user.UserService_ServiceDesc.ServiceName)
+	go checker.Run(workCtx, cfg.Health.ProbeInterval, cfg.Health.ProbeTimeout)
 
 	// Start the gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptor.Validate, userService.AuditInterceptor),
+		grpc.ChainStreamInterceptor(interceptor.ValidateStream),
+	)
 	user.RegisterUserServiceServer(grpcServer, userService)
+	grpc_health_v1.RegisterHealthServer(grpcServer, checker.Server())
 
 	lis, err := net.Listen(cfg.GrpcDetails.Network, cfg.GrpcDetails.Address)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
+	serveErr := make(chan error, 2)
 	go func() {
 		log.Println("Starting gRPC server on :50051")
 		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("Failed to serve gRPC: %v", err)
+			serveErr <- fmt.Errorf("serve gRPC: %w", err)
 		}
 	}()
 
 	ctx := context.Background()
-	mux := runtime.NewServeMux()
+	middlewares := []runtime.Middleware{
+		gateway.UpdateMaskFromBody(regexp.MustCompile(`^/v1/user/[^/]+$`), service.UpdatableUserFields...),
+	}
+	if !cfg.HttpDetails.ExposeAdminRoutes {
+		middlewares = append(middlewares, gateway.HidePaths(regexp.MustCompile(`^/v1/admin/`)))
+	}
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gateway.NewHeaderMatcher(cfg.HttpDetails.TrustActorHeader)),
+		runtime.WithForwardResponseOption(gateway.SetETag),
+		runtime.WithErrorHandler(gateway.ErrorHandler),
+		runtime.WithMarshalerOption(gateway.NDJSONContentType, gateway.NewNDJSONMarshaler()),
+		runtime.WithMarshalerOption(gateway.EventStreamContentType, gateway.NewEventStreamMarshaler()),
+		runtime.WithMiddlewares(middlewares...),
+	)
 	opts := []grpc.DialOption{grpc.WithInsecure()}
 	if err := user.RegisterUserServiceHandlerFromEndpoint(ctx, mux, cfg.GrpcDetails.Endpoint, opts); err != nil {
 		log.Fatalf("Failed to start REST gateway: %v", err)
 	}
+	if err := openapi.Register(mux, cfg.OpenAPI.SwaggerUIAssets); err != nil {
+		log.Fatalf("Failed to serve API docs: %v", err)
+	}
+	healthConn, err := grpc.NewClient(cfg.GrpcDetails.Endpoint, opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect health checks: %v", err)
+	}
+	defer healthConn.Close()
+	if err := healthcheck.Register(mux, grpc_health_v1.NewHealthClient(healthConn), user.UserService_ServiceDesc.ServiceName); err != nil {
+		log.Fatalf("Failed to serve health checks: %v", err)
+	}
+
+	httpServer := &http.Server{Addr: cfg.HttpDetails.Port, Handler: mux}
+	go func() {
+		log.Println("Starting REST server on :8080")
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("serve REST: %w", err)
+		}
+	}()
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+	select {
+	case <-signalCtx.Done():
+		log.Println("Received shutdown signal")
+	case err := <-serveErr:
+		log.Printf("Failed to %v", err)
+	}
+
+	shutdown(&cfg, checker, feed, httpServer, grpcServer)
+	stopWork()
+	closeStorage()
+	log.Println("Server stopped")
+}
 
-	log.Println("Starting REST server on :8080")
-	if err := http.ListenAndServe(cfg.HttpDetails.Port, mux); err != nil {
-		log.Fatalf("Failed to serve REST: %v", err)
+// shutdown stops serving in order: readiness is reported as failing first so
+// that load balancers stop sending new requests, the watch streams are ended,
+// the REST gateway drains its in-flight requests and the gRPC server finishes
+// the calls it is running. Requests still running after the shutdown timeout
+// are cancelled.
+func shutdown(cfg *config.Config, checker *healthcheck.Checker, feed *events.Broker, httpServer *http.Server, grpcServer *grpc.Server) {
+	timeout := cfg.Shutdown.Timeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	checker.Server().Shutdown()
+	if delay := cfg.Shutdown.ReadinessDelay; delay > 0 {
+		log.Printf("Reporting not ready for %s before draining", delay)
+		time.Sleep(delay)
+	}
+	feed.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Printf("Failed to drain REST server: %v", err)
+		httpServer.Close()
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Printf("gRPC server did not drain within %s, cancelling remaining calls", timeout)
+		grpcServer.Stop()
+		<-stopped
 	}
 }
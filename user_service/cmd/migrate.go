@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"user_service/config"
+	"user_service/internal/db"
+	"user_service/internal/db/migrations"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status"
+
+// runMigrate implements the "migrate" subcommand:
+//
+//	migrate up            apply all pending migrations
+//	migrate down [steps]  roll back the latest migrations (default 1)
+//	migrate status        list migrations and whether they are applied
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	cassandraSvc := db.NewCassandraDetailsSvc(cfg)
+	if args[0] == "up" {
+		if err := cassandraSvc.EnsureKeyspace(); err != nil {
+			log.Fatalf("Failed to create keyspace: %v", err)
+		}
+	}
+
+	session, err := cassandraSvc.Connect()
+	if err != nil {
+		log.Fatalf("Failed to connect to Cassandra: %v", err)
+	}
+	defer session.Close()
+
+	migrator, err := migrations.NewMigrator(session)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(ctx); err != nil {
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+		log.Println("Migrations applied")
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				log.Fatalf("Invalid number of steps %q", args[1])
+			}
+		}
+		if err := migrator.Down(ctx, steps); err != nil {
+			log.Fatalf("Failed to roll back migrations: %v", err)
+		}
+		log.Println("Migrations rolled back")
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, st := range statuses {
+			state, at := "pending", ""
+			if st.Applied {
+				state, at = "applied", st.AppliedAt.Format("2006-01-02 15:04:05Z07:00")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", st.Version, st.Name, state, at)
+		}
+		w.Flush()
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
@@ -1,10 +1,22 @@
 package config
 
+import "time"
+
 type Config struct {
+	Storage struct {
+		// Driver selects the user repository backend: "cassandra" (default) or "memory".
+		Driver string `yaml:"driver"`
+	} `yaml:"storage"`
+
 	CassandraDetails struct {
 		Address  string `yaml:"address"`
 		KeySpace string `yaml:"key_space"`
 		Port     int    `yaml:"port"`
+
+		// ReplicationFactor is used when the keyspace is created by the migrator.
+		ReplicationFactor int `yaml:"replication_factor"`
+		// AutoMigrate applies pending schema migrations when the server starts.
+		AutoMigrate bool `yaml:"auto_migrate"`
 	} `yaml:"cassandra_details"`
 
 	GrpcDetails struct {
@@ -13,7 +25,108 @@ type Config struct {
 		Endpoint string `yaml:"endpoint"`
 	} `yaml:"grpc_details"`
 
+	Idempotency struct {
+		// TTL is how long CreateUser responses are kept for Idempotency-Key replays.
+		TTL time.Duration `yaml:"ttl"`
+	} `yaml:"idempotency"`
+
+	Blocks struct {
+		// SweepInterval is how often expired timed blocks are lifted.
+		SweepInterval time.Duration `yaml:"sweep_interval"`
+	} `yaml:"blocks"`
+
+	Verification struct {
+		// CodeTTL is how long a contact verification code can be confirmed.
+		CodeTTL time.Duration `yaml:"code_ttl"`
+		// MaxAttempts is how often a code can be tried. The count carries over
+		// to resent codes, so once it is used up no new code is sent until the
+		// last one has expired.
+		MaxAttempts int `yaml:"max_attempts"`
+		// ResendCooldown is the minimum wait before another code is sent to
+		// the same contact.
+		ResendCooldown time.Duration `yaml:"resend_cooldown"`
+		// Notifier selects how codes are delivered: "file" appends them to
+		// NotifierFile and "dev-log" writes them to the server log, for local
+		// development only. Contact verification, and with it UpdateContact,
+		// answers Unimplemented when no notifier is set; the shipped
+		// conf/config.yaml uses "file".
+		Notifier     string `yaml:"notifier"`
+		NotifierFile string `yaml:"notifier_file"`
+	} `yaml:"verification"`
+
+	Events struct {
+		// Publisher selects where domain events are published besides the
+		// WatchUsers feed and the webhooks: "inprocess" (default) publishes
+		// them there only and "file" also appends them to File.
+		Publisher string `yaml:"publisher"`
+		File      string `yaml:"file"`
+		// RelayInterval is how often the outbox is checked for new events.
+		RelayInterval time.Duration `yaml:"relay_interval"`
+		// BatchSize is the maximum number of events published per check.
+		BatchSize int `yaml:"batch_size"`
+		// HistorySize is the number of recent events WatchUsers can resume
+		// from.
+		HistorySize int `yaml:"history_size"`
+	} `yaml:"events"`
+
+	Webhooks struct {
+		// Timeout bounds a single delivery attempt.
+		Timeout time.Duration `yaml:"timeout"`
+		// MaxAttempts is how often a delivery is attempted before it is
+		// dead-lettered.
+		MaxAttempts int `yaml:"max_attempts"`
+		// InitialBackoff is the wait before the first retry; every further
+		// retry waits twice as long, up to MaxBackoff.
+		InitialBackoff time.Duration `yaml:"initial_backoff"`
+		MaxBackoff     time.Duration `yaml:"max_backoff"`
+		// DispatchInterval is how often due deliveries are checked for.
+		DispatchInterval time.Duration `yaml:"dispatch_interval"`
+		// BatchSize is the maximum number of deliveries attempted per check.
+		BatchSize int `yaml:"batch_size"`
+		// Concurrency is the number of webhooks posted to at a time.
+		Concurrency int `yaml:"concurrency"`
+		// Retention is how long succeeded and dead-lettered deliveries are
+		// kept in the delivery log by the Cassandra store.
+		Retention time.Duration `yaml:"retention"`
+		// AllowPrivateNetworks accepts webhook URLs on loopback, private and
+		// link-local addresses. Only enable it for local development.
+		AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+	} `yaml:"webhooks"`
+
+	Health struct {
+		// ProbeInterval is how often Cassandra is probed for the health
+		// checks.
+		ProbeInterval time.Duration `yaml:"probe_interval"`
+		// ProbeTimeout bounds a single probe.
+		ProbeTimeout time.Duration `yaml:"probe_timeout"`
+	} `yaml:"health"`
+
+	Shutdown struct {
+		// Timeout bounds how long in-flight REST requests and gRPC calls are
+		// waited for after SIGTERM or SIGINT before they are cancelled.
+		Timeout time.Duration `yaml:"timeout"`
+		// ReadinessDelay is how long /readyz reports failure before the
+		// servers stop accepting requests, giving load balancers time to
+		// notice.
+		ReadinessDelay time.Duration `yaml:"readiness_delay"`
+	} `yaml:"shutdown"`
+
+	OpenAPI struct {
+		// SwaggerUIAssets is the base URL the Swagger UI at /docs loads
+		// swagger-ui-dist from, defaulting to the copy embedded in the binary.
+		SwaggerUIAssets string `yaml:"swagger_ui_assets"`
+	} `yaml:"openapi"`
+
 	HttpDetails struct {
 		Port string `yaml:"port"`
+		// TrustActorHeader forwards the X-Actor header to the service as the
+		// caller identity. Only enable it behind an authenticating proxy that
+		// sets the header; otherwise clients could impersonate any actor.
+		TrustActorHeader bool `yaml:"trust_actor_header"`
+		// ExposeAdminRoutes serves the /v1/admin routes, such as the
+		// permanent PurgeUser, on the REST gateway. They have no
+		// authorization of their own, so only enable it behind a proxy that
+		// restricts them to administrators; they are always served over gRPC.
+		ExposeAdminRoutes bool `yaml:"expose_admin_routes"`
 	} `yaml:"http_details"`
 }
@@ -0,0 +1,142 @@
+// Package apierror builds the gRPC status errors returned by the user service.
+//
+// Every error carries a google.rpc.ErrorInfo with a stable reason code, so
+// clients can branch on the cause without parsing messages, and validation
+// errors additionally carry a google.rpc.BadRequest naming the offending
+// fields. Errors without a domain meaning are logged and replaced by a generic
+// message so that storage errors never reach API clients.
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"log"
+	"user_service/internal/repository"
+)
+
+// Domain is the ErrorInfo domain of every error returned by the service.
+const Domain = "user_service"
+
+// Reason codes reported in ErrorInfo.reason.
+const (
+	ReasonValidationFailed     = "VALIDATION_FAILED"
+	ReasonUserNotFound         = "USER_NOT_FOUND"
+	ReasonDuplicateEmail       = "DUPLICATE_EMAIL"
+	ReasonDuplicatePhoneNumber = "DUPLICATE_PHONE_NUMBER"
+	ReasonUserBlocked          = "USER_BLOCKED"
+	ReasonVersionMismatch      = "VERSION_MISMATCH"
+	ReasonIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	ReasonRequestInProgress    = "REQUEST_IN_PROGRESS"
+	ReasonNotEnabled           = "NOT_ENABLED"
+
+	ReasonContactAlreadyVerified  = "CONTACT_ALREADY_VERIFIED"
+	ReasonContactChanged          = "CONTACT_CHANGED"
+	ReasonVerificationNotFound    = "VERIFICATION_NOT_FOUND"
+	ReasonInvalidVerificationCode = "INVALID_VERIFICATION_CODE"
+	ReasonTooManyAttempts         = "TOO_MANY_ATTEMPTS"
+	ReasonResendTooSoon           = "RESEND_TOO_SOON"
+
+	ReasonCursorExpired = "CURSOR_EXPIRED"
+	ReasonWatchTooSlow  = "WATCH_TOO_SLOW"
+	ReasonShuttingDown  = "SHUTTING_DOWN"
+
+	ReasonWebhookNotFound = "WEBHOOK_NOT_FOUND"
+
+	ReasonInternal = "INTERNAL"
+)
+
+// New returns a status error with the given code and message, an ErrorInfo
+// carrying reason and metadata, and any further details.
+func New(code codes.Code, reason string, metadata map[string]string, msg string, details ...protoadapt.MessageV1) error {
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: Domain, Metadata: metadata}
+	st, err := status.New(code, msg).WithDetails(append([]protoadapt.MessageV1{info}, details...)...)
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// FieldViolation describes a single invalid request field.
+func FieldViolation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: field, Description: description}
+}
+
+// InvalidArgument reports a request that failed validation. The violations
+// are attached as a BadRequest detail.
+func InvalidArgument(msg string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	var details []protoadapt.MessageV1
+	if len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	return New(codes.InvalidArgument, ReasonValidationFailed, nil, msg, details...)
+}
+
+// Internal logs err and reports a generic internal error for the failed
+// operation, e.g. "create user".
+func Internal(op string, err error) error {
+	log.Printf("Failed to %s: %v", op, err)
+	return New(codes.Internal, ReasonInternal, nil, fmt.Sprintf("Failed to %s", op))
+}
+
+// FromRepository maps an error returned by the repository while running op on
+// the user id to a status error. version is the version the caller expected,
+// zero if none. Errors without a domain meaning are reported as Internal.
+func FromRepository(op string, err error, id string, version int64) error {
+	metadata := map[string]string{}
+	if id != "" {
+		metadata["user_id"] = id
+	}
+
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		if id == "" {
+			return New(codes.NotFound, ReasonUserNotFound, nil, "User not found")
+		}
+		return New(codes.NotFound, ReasonUserNotFound, metadata, fmt.Sprintf("User %s not found", id))
+	case errors.Is(err, repository.ErrVersionMismatch):
+		metadata["expected_version"] = fmt.Sprint(version)
+		return New(codes.FailedPrecondition, ReasonVersionMismatch, metadata,
+			fmt.Sprintf("User %s has been modified since version %d", id, version))
+	case errors.Is(err, repository.ErrUserBlocked):
+		return New(codes.FailedPrecondition, ReasonUserBlocked, metadata, fmt.Sprintf("User %s is blocked", id))
+	case errors.Is(err, repository.ErrContactChanged):
+		return New(codes.FailedPrecondition, ReasonContactChanged, metadata,
+			fmt.Sprintf("The contact of user %s changed after the verification code was sent", id))
+	case errors.Is(err, repository.ErrVerificationNotFound):
+		return New(codes.FailedPrecondition, ReasonVerificationNotFound, metadata,
+			fmt.Sprintf("User %s has no pending verification, or its code has expired", id))
+	case errors.Is(err, repository.ErrDuplicateEmail):
+		return New(codes.AlreadyExists, ReasonDuplicateEmail, map[string]string{"field": "email"},
+			"A user with this email already exists")
+	case errors.Is(err, repository.ErrDuplicatePhoneNumber):
+		return New(codes.AlreadyExists, ReasonDuplicatePhoneNumber, map[string]string{"field": "phone_number"},
+			"A user with this phone_number already exists")
+	case errors.Is(err, repository.ErrInvalidPageToken):
+		return invalidPageToken()
+	default:
+		return Internal(op, err)
+	}
+}
+
+// FromWebhookStore maps an error returned by the webhook store while running
+// op on the webhook id to a status error.
+func FromWebhookStore(op string, err error, id string) error {
+	switch {
+	case errors.Is(err, repository.ErrWebhookNotFound):
+		return New(codes.NotFound, ReasonWebhookNotFound, map[string]string{"webhook_id": id},
+			fmt.Sprintf("Webhook %s not found", id))
+	case errors.Is(err, repository.ErrInvalidPageToken):
+		return invalidPageToken()
+	default:
+		return Internal(op, err)
+	}
+}
+
+func invalidPageToken() error {
+	return InvalidArgument("Invalid request: invalid page token",
+		FieldViolation("page_token", "page token was not returned by a previous call with the same filters"))
+}
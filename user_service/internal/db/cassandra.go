@@ -1,9 +1,12 @@
 package db
 
 import (
+	"context"
+	"fmt"
 	"github.com/gocql/gocql"
 	"log"
 	"user_service/config"
+	"user_service/internal/db/migrations"
 )
 
 type CassandraDetailsSvc struct {
@@ -16,15 +19,68 @@ func NewCassandraDetailsSvc(cfg *config.Config) *CassandraDetailsSvc {
 	}
 }
 
+// ConnectCassandra opens a session to the configured keyspace. When
+// auto_migrate is enabled the keyspace is created if needed and all pending
+// migrations are applied before the session is returned.
 func (a *CassandraDetailsSvc) ConnectCassandra() *gocql.Session {
+	autoMigrate := a.cfg.CassandraDetails.AutoMigrate
+	if autoMigrate {
+		if err := a.EnsureKeyspace(); err != nil {
+			log.Fatalf("Failed to create keyspace: %v", err)
+		}
+	}
 
-	cluster := gocql.NewCluster(a.cfg.CassandraDetails.Address)
-	cluster.Keyspace = a.cfg.CassandraDetails.KeySpace
-	cluster.Consistency = gocql.Quorum
-	cluster.Port = a.cfg.CassandraDetails.Port
-	session, err := cluster.CreateSession()
+	session, err := a.Connect()
 	if err != nil {
 		log.Fatalf("Failed to connect to Cassandra: %v", err)
 	}
+
+	if autoMigrate {
+		migrator, err := migrations.NewMigrator(session)
+		if err != nil {
+			log.Fatalf("Failed to load migrations: %v", err)
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+	}
 	return session
 }
+
+// Connect opens a session to the configured keyspace without touching the schema.
+func (a *CassandraDetailsSvc) Connect() (*gocql.Session, error) {
+	cluster := a.newCluster()
+	cluster.Keyspace = a.cfg.CassandraDetails.KeySpace
+	return cluster.CreateSession()
+}
+
+// EnsureKeyspace creates the configured keyspace with SimpleStrategy
+// replication if it does not exist yet.
+func (a *CassandraDetailsSvc) EnsureKeyspace() error {
+	session, err := a.newCluster().CreateSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	replicationFactor := a.cfg.CassandraDetails.ReplicationFactor
+	if replicationFactor <= 0 {
+		replicationFactor = 1
+	}
+	query := fmt.Sprintf(`CREATE KEYSPACE IF NOT EXISTS %q WITH replication = {'class': 'SimpleStrategy', 'replication_factor': %d}`,
+		a.cfg.CassandraDetails.KeySpace, replicationFactor)
+	return session.Query(query).Exec()
+}
+
+func (a *CassandraDetailsSvc) newCluster() *gocql.ClusterConfig {
+	cluster := gocql.NewCluster(a.cfg.CassandraDetails.Address)
+	cluster.Consistency = gocql.Quorum
+	cluster.Port = a.cfg.CassandraDetails.Port
+	return cluster
+}
+
+// Ping runs a trivial query on the session, failing when no Cassandra node can
+// answer it.
+func Ping(ctx context.Context, session *gocql.Session) error {
+	return session.Query(`SELECT release_version FROM system.local`).WithContext(ctx).Exec()
+}
@@ -0,0 +1,166 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"github.com/gocql/gocql"
+	"log"
+	"time"
+	"user_service/internal/repository"
+	"user_service/internal/repository/cassandra"
+)
+
+// funcMigrations are the migrations that rewrite data rather than schema and
+// so are written in Go. Their Down is a no-op: the data they fill in stays
+// valid when the migrations before them are rolled back.
+var funcMigrations = []Migration{
+	{Version: 13, Name: "backfill_legacy_users", UpFunc: backfillLegacyUsers},
+	{Version: 15, Name: "mark_pending_outbox", UpFunc: markPendingOutbox},
+	{Version: 17, Name: "queue_pending_deliveries", UpFunc: queuePendingDeliveries},
+}
+
+// backfillLegacyUsers fills in what migrations 0002 to 0004 added for users
+// created before them: deleted = false and version = 1 where they are null,
+// which the versioned writes condition on, created_at and updated_at, and the
+// users_by_email and users_by_phone entries of live users. Every write is
+// conditional, so the backfill can be rerun and races safely with the
+// service.
+func backfillLegacyUsers(ctx context.Context, session *gocql.Session) error {
+	iter := session.Query(`SELECT id, email, phone_number, deleted, version, created_at, updated_at, WRITETIME(first_name) FROM users`).WithContext(ctx).Iter()
+	var (
+		id                   gocql.UUID
+		email, phoneNumber   string
+		deleted              *bool
+		version              *int64
+		createdAt, updatedAt *time.Time
+		written              *int64
+	)
+	for iter.Scan(&id, &email, &phoneNumber, &deleted, &version, &createdAt, &updatedAt, &written) {
+		if deleted == nil {
+			if err := casExec(ctx, session, `UPDATE users SET deleted = false WHERE id = ? IF deleted = null`, id); err != nil {
+				return fmt.Errorf("user %s: %w", id, err)
+			}
+		}
+		if version == nil {
+			if err := casExec(ctx, session, `UPDATE users SET version = 1 WHERE id = ? IF version = null`, id); err != nil {
+				return fmt.Errorf("user %s: %w", id, err)
+			}
+		}
+		if createdAt == nil || updatedAt == nil {
+			at := legacyTimestamp(updatedAt, written)
+			if createdAt == nil {
+				if err := casExec(ctx, session, `UPDATE users SET created_at = ? WHERE id = ? IF created_at = null`, at, id); err != nil {
+					return fmt.Errorf("user %s: %w", id, err)
+				}
+			}
+			if updatedAt == nil {
+				if err := casExec(ctx, session, `UPDATE users SET updated_at = ? WHERE id = ? IF updated_at = null`, at, id); err != nil {
+					return fmt.Errorf("user %s: %w", id, err)
+				}
+			}
+		}
+		if deleted != nil && *deleted {
+			continue
+		}
+		for _, lookup := range []struct{ table, column, key string }{
+			{"users_by_email", "email", email},
+			{"users_by_phone", "phone_number", phoneNumber},
+		} {
+			if lookup.key == "" {
+				continue
+			}
+			if err := backfillLookup(ctx, session, lookup.table, lookup.column, lookup.key, id); err != nil {
+				return fmt.Errorf("user %s: %w", id, err)
+			}
+		}
+	}
+	return iter.Close()
+}
+
+// legacyTimestamp is the best known creation time of a legacy user: its
+// updated_at if set, otherwise when its first_name was written, which
+// WRITETIME reports in microseconds.
+func legacyTimestamp(updatedAt *time.Time, written *int64) time.Time {
+	switch {
+	case updatedAt != nil && !updatedAt.IsZero():
+		return *updatedAt
+	case written != nil:
+		return time.UnixMicro(*written).UTC().Truncate(time.Millisecond)
+	}
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// markPendingOutbox marks the users whose outbox holds events in the
+// outbox_pending table added by migration 0014, which the relay reads instead
+// of scanning the users table. Events recorded before it would otherwise
+// never be published.
+func markPendingOutbox(ctx context.Context, session *gocql.Session) error {
+	iter := session.Query(`SELECT id, outbox FROM users`).WithContext(ctx).Iter()
+	var (
+		id     gocql.UUID
+		outbox map[gocql.UUID]string
+	)
+	for iter.Scan(&id, &outbox) {
+		if len(outbox) == 0 {
+			continue
+		}
+		if err := session.Query(`INSERT INTO outbox_pending (bucket, user_id, marked_at) VALUES (?, ?, ?)`,
+			cassandra.OutboxBucket(id.String()), id, time.Now().UTC()).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("user %s: %w", id, err)
+		}
+		outbox = nil
+	}
+	return iter.Close()
+}
+
+// queuePendingDeliveries queues the pending webhook deliveries in the
+// webhook_due table added by migration 0016, which the dispatcher reads
+// instead of filtering webhook_deliveries.
+func queuePendingDeliveries(ctx context.Context, session *gocql.Session) error {
+	iter := session.Query(`SELECT webhook_id, event_id, status, next_attempt_at FROM webhook_deliveries`).WithContext(ctx).Iter()
+	var (
+		webhookID, eventID gocql.UUID
+		status             string
+		dueAt              *time.Time
+	)
+	for iter.Scan(&webhookID, &eventID, &status, &dueAt) {
+		if status != string(repository.DeliveryPending) || dueAt == nil {
+			continue
+		}
+		bucket := cassandra.DueBucket(*dueAt)
+		if err := session.Query(`INSERT INTO webhook_due_buckets (shard, bucket) VALUES (0, ?)`, bucket).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("delivery %s of webhook %s: %w", eventID, webhookID, err)
+		}
+		if err := session.Query(`INSERT INTO webhook_due (bucket, due_at, webhook_id, event_id) VALUES (?, ?, ?, ?)`,
+			bucket, *dueAt, webhookID, eventID).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("delivery %s of webhook %s: %w", eventID, webhookID, err)
+		}
+	}
+	return iter.Close()
+}
+
+// backfillLookup claims key for id in a lookup table. Legacy rows were never
+// checked for uniqueness, so a key already owned by another user is logged
+// and left to its first claimant.
+func backfillLookup(ctx context.Context, session *gocql.Session, table, column, key string, id gocql.UUID) error {
+	query := `INSERT INTO ` + table + ` (` + column + `, user_id) VALUES (?, ?) IF NOT EXISTS`
+	var (
+		existingKey string
+		owner       gocql.UUID
+	)
+	applied, err := session.Query(query, key, id).WithContext(ctx).ScanCAS(&existingKey, &owner)
+	if err != nil {
+		return err
+	}
+	if !applied && owner != id {
+		log.Printf("Not backfilling %s %q for user %s: already owned by user %s", column, key, id, owner)
+	}
+	return nil
+}
+
+// casExec runs a lightweight transaction whose outcome does not matter to the
+// caller: if it was not applied, another writer already filled the column in.
+func casExec(ctx context.Context, session *gocql.Session, query string, values ...interface{}) error {
+	_, err := session.Query(query, values...).WithContext(ctx).MapScanCAS(make(map[string]interface{}))
+	return err
+}
@@ -0,0 +1,211 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/gocql/gocql"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed cql/*.cql
+var files embed.FS
+
+// Migration is a versioned pair of CQL scripts embedded in the binary.
+// Scripts live in cql/ and are named <version>_<name>.up.cql and
+// <version>_<name>.down.cql. Data migrations have no scripts and run UpFunc
+// instead.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	UpFunc  func(ctx context.Context, session *gocql.Session) error
+}
+
+// Status describes whether a migration has been applied to the keyspace.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies the embedded migrations to a keyspace and records them in
+// the schema_migrations table.
+type Migrator struct {
+	session    *gocql.Session
+	migrations []Migration
+}
+
+// NewMigrator returns a Migrator for the keyspace the session is bound to.
+func NewMigrator(session *gocql.Session) (*Migrator, error) {
+	migrations, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{session: session, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order.
+func (m *Migrator) Up(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if err := m.exec(ctx, mig.Up); err != nil {
+			return fmt.Errorf("applying migration %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+		if mig.UpFunc != nil {
+			if err := mig.UpFunc(ctx, m.session); err != nil {
+				return fmt.Errorf("applying migration %04d_%s: %w", mig.Version, mig.Name, err)
+			}
+		}
+		if err := m.session.Query(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+			mig.Version, mig.Name, time.Now().UTC()).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("recording migration %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+	}
+	return nil
+}
+
+// Down rolls back the given number of most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+		mig := m.migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		if err := m.exec(ctx, mig.Down); err != nil {
+			return fmt.Errorf("rolling back migration %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+		if err := m.session.Query(`DELETE FROM schema_migrations WHERE version = ?`, mig.Version).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("unrecording migration %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+		steps--
+	}
+	return nil
+}
+
+// Status reports every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		at, ok := applied[mig.Version]
+		statuses = append(statuses, Status{Migration: mig, Applied: ok, AppliedAt: at})
+	}
+	return statuses, nil
+}
+
+// applied creates the tracking table if needed and returns the applied
+// versions with their timestamps.
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	if err := m.session.Query(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version int PRIMARY KEY,
+		name text,
+		applied_at timestamp
+	)`).WithContext(ctx).Exec(); err != nil {
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	applied := make(map[int]time.Time)
+	iter := m.session.Query(`SELECT version, applied_at FROM schema_migrations`).WithContext(ctx).Iter()
+	var (
+		version   int
+		appliedAt time.Time
+	)
+	for iter.Scan(&version, &appliedAt) {
+		applied[version] = appliedAt
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("reading schema_migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// exec runs each semicolon terminated statement of a script.
+func (m *Migrator) exec(ctx context.Context, script string) error {
+	for _, stmt := range strings.Split(script, ";") {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+		if err := m.session.Query(stmt).WithContext(ctx).Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load reads the embedded scripts, pairs up/down files by version and adds
+// the data migrations.
+func load() ([]Migration, error) {
+	names, err := fs.Glob(files, "cql/*.cql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, name := range names {
+		base := path.Base(name)
+		prefix, rest, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: missing version prefix", base)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %w", base, err)
+		}
+		body, err := files.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version}
+			byVersion[version] = mig
+		}
+		switch {
+		case strings.HasSuffix(rest, ".up.cql"):
+			mig.Name = strings.TrimSuffix(rest, ".up.cql")
+			mig.Up = string(body)
+		case strings.HasSuffix(rest, ".down.cql"):
+			mig.Down = string(body)
+		default:
+			return nil, fmt.Errorf("migration %s: expected .up.cql or .down.cql suffix", base)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %04d: both up and down scripts are required", mig.Version)
+		}
+		migrations = append(migrations, *mig)
+	}
+	for _, mig := range funcMigrations {
+		if _, ok := byVersion[mig.Version]; ok {
+			return nil, fmt.Errorf("migration %04d: defined both as scripts and in Go", mig.Version)
+		}
+		migrations = append(migrations, mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
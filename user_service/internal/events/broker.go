@@ -0,0 +1,214 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"user_service/protogen/user"
+)
+
+var (
+	// ErrInvalidCursor is returned by Subscribe for cursors it did not issue.
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrCursorExpired is returned by Subscribe for cursors of events that
+	// are no longer held, or that were issued by another process.
+	ErrCursorExpired = errors.New("cursor expired")
+
+	// ErrSubscriberTooSlow is reported by Subscription.Err when the
+	// subscriber was dropped for falling behind.
+	ErrSubscriberTooSlow = errors.New("subscriber fell behind")
+
+	// ErrBrokerClosed is reported by Subscription.Err when the broker was
+	// closed.
+	ErrBrokerClosed = errors.New("broker closed")
+)
+
+// defaultHistorySize is used by NewBroker when given no history size.
+const defaultHistorySize = 1000
+
+// Envelope is an event delivered by the Broker together with the cursor to
+// resume after it.
+type Envelope struct {
+	Cursor string
+	Event  *user.UserEvent
+}
+
+// Broker is an in-process EventPublisher that fans events out to the
+// subscribers of the same process. It keeps the most recent events so that a
+// subscriber can resume from the cursor of the last event it received.
+// Cursors embed a random epoch, so cursors of a previous process are reported
+// as expired instead of being mistaken for cursors of this one.
+type Broker struct {
+	mu      sync.Mutex
+	epoch   string
+	seq     uint64
+	history []Envelope
+	size    int
+	held    map[string]bool
+	subs    map[*Subscription]struct{}
+	closed  bool
+}
+
+var _ EventPublisher = (*Broker)(nil)
+
+// NewBroker returns a broker holding the last historySize events.
+func NewBroker(historySize int) *Broker {
+	if historySize <= 0 {
+		historySize = defaultHistorySize
+	}
+	epoch := make([]byte, 8)
+	if _, err := rand.Read(epoch); err != nil {
+		panic(err)
+	}
+	return &Broker{
+		epoch: hex.EncodeToString(epoch),
+		size:  historySize,
+		held:  make(map[string]bool),
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events published after it was created, preceded
+// by the held events after its cursor.
+type Subscription struct {
+	broker *Broker
+	events chan Envelope
+	err    error
+	once   sync.Once
+}
+
+// Subscribe registers a subscriber buffering up to buffer live events. An
+// empty cursor starts with the next published event. A subscriber that falls
+// further behind is dropped: its channel is closed and Err reports
+// ErrSubscriberTooSlow.
+func (b *Broker) Subscribe(cursor string, buffer int) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Envelope
+	if cursor != "" {
+		epoch, seq, err := parseCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if epoch != b.epoch || seq > b.seq {
+			return nil, ErrCursorExpired
+		}
+		oldest := b.seq - uint64(len(b.history)) + 1
+		if seq+1 < oldest {
+			return nil, ErrCursorExpired
+		}
+		replay = b.history[len(b.history)-int(b.seq-seq):]
+	}
+
+	s := &Subscription{broker: b, events: make(chan Envelope, buffer+len(replay))}
+	for _, env := range replay {
+		s.events <- env
+	}
+	if b.closed {
+		s.close(ErrBrokerClosed)
+		return s, nil
+	}
+	b.subs[s] = struct{}{}
+	return s, nil
+}
+
+// Events returns the channel the subscriber's events are delivered on. It is
+// closed when the subscription ends.
+func (s *Subscription) Events() <-chan Envelope {
+	return s.events
+}
+
+// Err reports why the events channel was closed by the broker, and is nil
+// while the subscription is active or after Close.
+func (s *Subscription) Err() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	return s.err
+}
+
+// Close unsubscribes and closes the events channel.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	delete(s.broker.subs, s)
+	s.close(nil)
+}
+
+// close ends the subscription with err. The caller must hold the broker lock.
+func (s *Subscription) close(err error) {
+	s.once.Do(func() {
+		s.err = err
+		close(s.events)
+	})
+}
+
+// Publish records the event and hands it to every subscriber without
+// blocking. Events already held, such as those republished by the outbox
+// relay after a failure, are ignored.
+func (b *Broker) Publish(ctx context.Context, e *user.UserEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.held[e.GetEventId()] {
+		return nil
+	}
+	b.seq++
+	env := Envelope{Cursor: formatCursor(b.epoch, b.seq), Event: e}
+	if len(b.history) == b.size {
+		delete(b.held, b.history[0].Event.GetEventId())
+		b.history = append(b.history[:0], b.history[1:]...)
+	}
+	b.history = append(b.history, env)
+	b.held[e.GetEventId()] = true
+
+	for s := range b.subs {
+		select {
+		case s.events <- env:
+		default:
+			delete(b.subs, s)
+			s.close(ErrSubscriberTooSlow)
+		}
+	}
+	return nil
+}
+
+// Close ends every subscription and rejects new ones.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for s := range b.subs {
+		delete(b.subs, s)
+		s.close(ErrBrokerClosed)
+	}
+}
+
+func formatCursor(epoch string, seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(epoch + ":" + strconv.FormatUint(seq, 10)))
+}
+
+func parseCursor(cursor string) (string, uint64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, ErrInvalidCursor
+	}
+	epoch, seq, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return "", 0, ErrInvalidCursor
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return "", 0, ErrInvalidCursor
+	}
+	return epoch, n, nil
+}
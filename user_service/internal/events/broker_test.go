@@ -0,0 +1,120 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"user_service/protogen/user"
+)
+
+func publishN(t *testing.T, b *Broker, from, to int) {
+	t.Helper()
+	for i := from; i <= to; i++ {
+		if err := b.Publish(context.Background(), &user.UserEvent{EventId: fmt.Sprintf("e%d", i)}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+}
+
+// receive reads n events from the subscription, which must have them buffered.
+func receive(t *testing.T, s *Subscription, n int) []Envelope {
+	t.Helper()
+	var envs []Envelope
+	for i := 0; i < n; i++ {
+		select {
+		case env, ok := <-s.Events():
+			if !ok {
+				t.Fatalf("subscription ended after %d events: %v", i, s.Err())
+			}
+			envs = append(envs, env)
+		default:
+			t.Fatalf("got %d events, want %d", i, n)
+		}
+	}
+	return envs
+}
+
+func eventIDs(envs []Envelope) string {
+	var ids string
+	for _, env := range envs {
+		ids += env.Event.GetEventId() + " "
+	}
+	return ids
+}
+
+func TestBrokerCursors(t *testing.T) {
+	b := NewBroker(3)
+	live, err := b.Subscribe("", 10)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	publishN(t, b, 1, 5)
+	// An event that is still held is not published again.
+	publishN(t, b, 5, 5)
+	envs := receive(t, live, 5)
+	if got := eventIDs(envs); got != "e1 e2 e3 e4 e5 " {
+		t.Fatalf("live events %q, want e1 to e5", got)
+	}
+
+	for _, tt := range []struct {
+		cursor  string
+		want    string
+		wantErr error
+	}{
+		{cursor: envs[1].Cursor, want: "e3 e4 e5 "},
+		{cursor: envs[3].Cursor, want: "e5 "},
+		{cursor: envs[4].Cursor, want: ""},
+		{cursor: envs[0].Cursor, wantErr: ErrCursorExpired},
+		{cursor: formatCursor(b.epoch, 6), wantErr: ErrCursorExpired},
+		{cursor: formatCursor("other", 4), wantErr: ErrCursorExpired},
+		{cursor: "not a cursor", wantErr: ErrInvalidCursor},
+	} {
+		s, err := b.Subscribe(tt.cursor, 10)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Subscribe(%q) error = %v, want %v", tt.cursor, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Subscribe(%q): %v", tt.cursor, err)
+		}
+		if got := eventIDs(receive(t, s, len(s.Events()))); got != tt.want {
+			t.Errorf("Subscribe(%q) replayed %q, want %q", tt.cursor, got, tt.want)
+		}
+		s.Close()
+	}
+}
+
+func TestBrokerEndsSubscriptions(t *testing.T) {
+	b := NewBroker(10)
+	slow, err := b.Subscribe("", 1)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	fast, err := b.Subscribe("", 10)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	publishN(t, b, 1, 2)
+
+	// The buffered event is still delivered before the channel is closed.
+	receive(t, slow, 1)
+	if _, ok := <-slow.Events(); ok || !errors.Is(slow.Err(), ErrSubscriberTooSlow) {
+		t.Errorf("slow subscription error = %v, want %v", slow.Err(), ErrSubscriberTooSlow)
+	}
+
+	b.Close()
+	receive(t, fast, 2)
+	if _, ok := <-fast.Events(); ok || !errors.Is(fast.Err(), ErrBrokerClosed) {
+		t.Errorf("subscription error after Close = %v, want %v", fast.Err(), ErrBrokerClosed)
+	}
+	late, err := b.Subscribe("", 1)
+	if err != nil {
+		t.Fatalf("Subscribe after Close: %v", err)
+	}
+	if _, ok := <-late.Events(); ok || !errors.Is(late.Err(), ErrBrokerClosed) {
+		t.Errorf("subscription error after Close = %v, want %v", late.Err(), ErrBrokerClosed)
+	}
+}
@@ -0,0 +1,71 @@
+// Package events publishes the domain events of the user service to
+// downstream consumers.
+package events
+
+import (
+	"context"
+	"google.golang.org/protobuf/encoding/protojson"
+	"os"
+	"sync"
+	"user_service/protogen/user"
+)
+
+// EventPublisher delivers user events to a broker or another transport.
+// Publish returns only once the event has been accepted; an event whose
+// publication failed is published again later, so implementations must
+// tolerate duplicates. Implementations must be safe for concurrent use.
+type EventPublisher interface {
+	Publish(ctx context.Context, e *user.UserEvent) error
+}
+
+// FilePublisher appends events to a file as newline delimited protobuf JSON.
+// It is meant for local development and for consumers that tail the file
+// instead of reading from a broker.
+type FilePublisher struct {
+	mu   sync.Mutex
+	path string
+}
+
+var _ EventPublisher = (*FilePublisher)(nil)
+
+func NewFilePublisher(path string) *FilePublisher {
+	return &FilePublisher{path: path}
+}
+
+// Publish appends the event to the file, creating it if needed.
+func (p *FilePublisher) Publish(ctx context.Context, e *user.UserEvent) error {
+	line, err := protojson.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Fanout publishes every event to each of its publishers in order, stopping
+// at the first failure. The whole event is retried by the relay, so the
+// publishers before the failing one must tolerate duplicates.
+type Fanout []EventPublisher
+
+var _ EventPublisher = Fanout(nil)
+
+// Publish hands the event to every publisher.
+func (f Fanout) Publish(ctx context.Context, e *user.UserEvent) error {
+	for _, p := range f {
+		if err := p.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package gateway
+
+import (
+	"context"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"strconv"
+	"user_service/protogen/user"
+)
+
+// SetETag is a forward response option that exposes the version of a returned
+// user as a strong ETag, which clients send back in If-Match.
+func SetETag(ctx context.Context, w http.ResponseWriter, msg proto.Message) error {
+	if u, ok := msg.(*user.UserResponse); ok && u.GetVersion() > 0 {
+		w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(u.GetVersion(), 10)))
+	}
+	return nil
+}
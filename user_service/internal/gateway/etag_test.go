@@ -0,0 +1,56 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"user_service/protogen/user"
+)
+
+func TestIfMatchPreconditionFailed(t *testing.T) {
+	mux, srv := newGateway(t)
+	created, err := srv.CreateUser(context.Background(), &user.CreateUserRequest{
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		Gender:      "Female",
+		DateOfBirth: "1990-01-02",
+		Email:       "jane@example.com",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	for _, tt := range []struct {
+		ifMatch    string
+		wantStatus int
+	}{
+		{`"2"`, http.StatusPreconditionFailed},
+		{`"1"`, http.StatusOK},
+		// The user is now at version 2, so the old ETag is stale.
+		{`"1"`, http.StatusPreconditionFailed},
+	} {
+		req := httptest.NewRequest(http.MethodPatch, "/v1/user/"+created.GetId(), strings.NewReader(`{"first_name":"Zoe"}`))
+		req.Header.Set("If-Match", tt.ifMatch)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != tt.wantStatus {
+			t.Fatalf("PATCH with If-Match %s = %d, want %d: %s", tt.ifMatch, rec.Code, tt.wantStatus, rec.Body)
+		}
+		if rec.Code == http.StatusOK {
+			continue
+		}
+		var problem struct {
+			Status int    `json:"status"`
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("decode problem: %v", err)
+		}
+		if problem.Status != http.StatusPreconditionFailed || problem.Reason != "VERSION_MISMATCH" {
+			t.Errorf("problem = %+v, want status 412 with reason VERSION_MISMATCH", problem)
+		}
+	}
+}
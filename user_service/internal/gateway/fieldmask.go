@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// UpdateMaskFromBody returns a runtime.Middleware for PATCH requests to paths
+// matching path whose JSON body has no update_mask. It sets the mask to the
+// given fields that are present in the body, so that a partial document only
+// changes the fields it contains instead of replacing all of them. Fields are
+// matched by their proto or JSON name.
+func UpdateMaskFromBody(path *regexp.Regexp, fields ...string) runtime.Middleware {
+	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			if r.Method == http.MethodPatch && path.MatchString(r.URL.Path) {
+				addUpdateMask(r, fields)
+			}
+			next(w, r, pathParams)
+		}
+	}
+}
+
+// addUpdateMask rewrites the body of r. Bodies that are not JSON objects are
+// left for the gateway to reject.
+func addUpdateMask(r *http.Request, fields []string) {
+	raw, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+	if err != nil {
+		return
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return
+	}
+	if _, ok := body["update_mask"]; ok {
+		return
+	}
+	if _, ok := body["updateMask"]; ok {
+		return
+	}
+
+	var paths []string
+	for _, f := range fields {
+		jsonName := lowerCamel(f)
+		_, ok := body[f]
+		if _, okJSON := body[jsonName]; ok || okJSON {
+			paths = append(paths, jsonName)
+		}
+	}
+	mask, _ := json.Marshal(strings.Join(paths, ","))
+	body["updateMask"] = mask
+	if raw, err = json.Marshal(body); err != nil {
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+	r.ContentLength = int64(len(raw))
+}
+
+// lowerCamel returns the JSON name of a proto field name, e.g. first_name
+// becomes firstName.
+func lowerCamel(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,83 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"user_service/internal/gateway"
+	"user_service/internal/repository/memory"
+	"user_service/internal/service"
+	"user_service/protogen/user"
+)
+
+func newGateway(t *testing.T) (*runtime.ServeMux, *service.UserServiceServer) {
+	t.Helper()
+	srv := service.NewUserServiceServer(memory.NewUserRepository())
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gateway.NewHeaderMatcher(false)),
+		runtime.WithErrorHandler(gateway.ErrorHandler),
+		runtime.WithMiddlewares(gateway.UpdateMaskFromBody(regexp.MustCompile(`^/v1/user/[^/]+$`), service.UpdatableUserFields...)),
+	)
+	if err := user.RegisterUserServiceHandlerServer(context.Background(), mux, srv); err != nil {
+		t.Fatalf("register gateway: %v", err)
+	}
+	return mux, srv
+}
+
+func TestUpdateMaskFromBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantFirst  string
+		wantLast   string
+	}{
+		{"patch single field", http.MethodPatch, `{"first_name":"Zoe"}`, http.StatusOK, "Zoe", "Doe"},
+		{"patch json name", http.MethodPatch, `{"firstName":"Zoe"}`, http.StatusOK, "Zoe", "Doe"},
+		{"patch explicit mask", http.MethodPatch, `{"first_name":"Zoe","last_name":"Roe","update_mask":"lastName"}`, http.StatusOK, "Jane", "Roe"},
+		{"put replaces all fields", http.MethodPut, `{"first_name":"Zoe"}`, http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, srv := newGateway(t)
+			created, err := srv.CreateUser(context.Background(), &user.CreateUserRequest{
+				FirstName:   "Jane",
+				LastName:    "Doe",
+				Gender:      "Female",
+				DateOfBirth: "1990-01-02",
+				Email:       "jane@example.com",
+			})
+			if err != nil {
+				t.Fatalf("CreateUser: %v", err)
+			}
+
+			req := httptest.NewRequest(tt.method, "/v1/user/"+created.GetId(), strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var got struct {
+				FirstName string `json:"firstName"`
+				LastName  string `json:"lastName"`
+				Gender    string `json:"gender"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if got.FirstName != tt.wantFirst || got.LastName != tt.wantLast || got.Gender != "Female" {
+				t.Errorf("user = %+v, want first name %q, last name %q, gender Female", got, tt.wantFirst, tt.wantLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package gateway
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"net/textproto"
+	"strings"
+)
+
+// forwardedHeaders are HTTP request headers passed to the gRPC service as
+// metadata under their lower-cased name instead of the "grpcgateway-" prefixed
+// key used by runtime.DefaultHeaderMatcher.
+var forwardedHeaders = map[string]bool{
+	"If-Match":        true,
+	"Idempotency-Key": true,
+	"Last-Event-Id":   true,
+	"X-Request-Id":    true,
+}
+
+// actorHeader names the caller recorded in the audit log and block history.
+// Any client can set it, so it is only forwarded from a trusted proxy.
+const actorHeader = "X-Actor"
+
+// NewHeaderMatcher returns a runtime.HeaderMatcherFunc that forwards the
+// headers the user service understands and falls back to the default
+// behaviour otherwise. X-Actor is dropped unless trustActor is set, which is
+// only safe when the gateway is reachable solely through an authenticating
+// proxy that sets or strips the header.
+func NewHeaderMatcher(trustActor bool) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		canonical := textproto.CanonicalMIMEHeaderKey(key)
+		switch {
+		case forwardedHeaders[canonical], trustActor && canonical == actorHeader:
+			return strings.ToLower(canonical), true
+		case canonical == actorHeader:
+			return "", false
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
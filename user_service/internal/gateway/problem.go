@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"net/http"
+	"user_service/internal/apierror"
+)
+
+// problemContentType is the media type of RFC 7807 problem details.
+const problemContentType = "application/problem+json"
+
+// problem is an RFC 7807 problem details object. Code, Reason, Metadata and
+// InvalidParams are extension members carrying the gRPC status code and the
+// ErrorInfo and BadRequest details of the error.
+type problem struct {
+	Type          string            `json:"type"`
+	Title         string            `json:"title"`
+	Status        int               `json:"status"`
+	Detail        string            `json:"detail,omitempty"`
+	Instance      string            `json:"instance,omitempty"`
+	Code          string            `json:"code"`
+	Reason        string            `json:"reason,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	InvalidParams []invalidParam    `json:"invalid-params,omitempty"`
+}
+
+type invalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ErrorHandler writes gRPC errors as application/problem+json. The HTTP status
+// follows the gRPC code, except that a failed If-Match or expected_version
+// check is reported as 412 Precondition Failed.
+func ErrorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	httpStatus := 0
+	var statusErr *runtime.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		httpStatus, err = statusErr.HTTPStatus, statusErr.Err
+	}
+
+	st := status.Convert(err)
+	p := problem{
+		Type:     "about:blank",
+		Detail:   st.Message(),
+		Instance: r.URL.Path,
+		Code:     st.Code().String(),
+	}
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			p.Type = "urn:" + d.GetDomain() + ":" + d.GetReason()
+			p.Reason = d.GetReason()
+			p.Metadata = d.GetMetadata()
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				p.InvalidParams = append(p.InvalidParams, invalidParam{Name: v.GetField(), Reason: v.GetDescription()})
+			}
+		}
+	}
+
+	if httpStatus == 0 {
+		httpStatus = runtime.HTTPStatusFromCode(st.Code())
+		if p.Reason == apierror.ReasonVersionMismatch || (st.Code() == codes.FailedPrecondition && p.Reason == "") {
+			httpStatus = http.StatusPreconditionFailed
+		}
+	}
+	p.Status = httpStatus
+	p.Title = http.StatusText(httpStatus)
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		log.Printf("Failed to marshal problem details: %v", err)
+		body = []byte(`{"type":"about:blank","title":"Internal Server Error","status":500}`)
+		httpStatus = http.StatusInternalServerError
+	}
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(httpStatus)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("Failed to write problem details: %v", err)
+	}
+}
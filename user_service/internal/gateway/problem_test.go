@@ -0,0 +1,69 @@
+package gateway_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProblemDetails(t *testing.T) {
+	tests := []struct {
+		name          string
+		method, path  string
+		body          string
+		wantStatus    int
+		wantReason    string
+		wantParameter string
+	}{
+		{
+			name:       "unknown user",
+			method:     http.MethodPost,
+			path:       "/v1/user/5b7f0c4e-3f8a-4d6e-9f61-1d2c3b4a5e6f/block",
+			body:       `{}`,
+			wantStatus: http.StatusNotFound,
+			wantReason: "USER_NOT_FOUND",
+		},
+		{
+			name:          "invalid page token",
+			method:        http.MethodGet,
+			path:          "/v1/users?page_token=not%20base64!",
+			wantStatus:    http.StatusBadRequest,
+			wantReason:    "VALIDATION_FAILED",
+			wantParameter: "page_token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, _ := newGateway(t)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want application/problem+json", ct)
+			}
+			var p struct {
+				Type          string `json:"type"`
+				Status        int    `json:"status"`
+				Reason        string `json:"reason"`
+				Instance      string `json:"instance"`
+				InvalidParams []struct {
+					Name string `json:"name"`
+				} `json:"invalid-params"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if p.Status != tt.wantStatus || p.Reason != tt.wantReason || !strings.HasSuffix(p.Type, ":"+tt.wantReason) {
+				t.Errorf("problem = %+v, want status %d with reason %s", p, tt.wantStatus, tt.wantReason)
+			}
+			if tt.wantParameter != "" && (len(p.InvalidParams) != 1 || p.InvalidParams[0].Name != tt.wantParameter) {
+				t.Errorf("invalid-params = %+v, want %s", p.InvalidParams, tt.wantParameter)
+			}
+		})
+	}
+}
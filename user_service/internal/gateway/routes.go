@@ -0,0 +1,25 @@
+package gateway
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"regexp"
+)
+
+// HidePaths returns a runtime.Middleware that answers requests to paths
+// matching path with 404 Not Found, as if the route did not exist. It keeps
+// RPCs such as PurgeUser, which have no authorization of their own, off a
+// gateway that is reachable by API clients; they stay available over gRPC.
+func HidePaths(path *regexp.Regexp) runtime.Middleware {
+	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			if path.MatchString(r.URL.Path) {
+				ErrorHandler(r.Context(), nil, nil, w, r, status.Error(codes.NotFound, http.StatusText(http.StatusNotFound)))
+				return
+			}
+			next(w, r, pathParams)
+		}
+	}
+}
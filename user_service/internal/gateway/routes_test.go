@@ -0,0 +1,52 @@
+package gateway_test
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"user_service/internal/gateway"
+	"user_service/internal/repository/memory"
+	"user_service/internal/service"
+	"user_service/protogen/user"
+)
+
+func TestHidePaths(t *testing.T) {
+	srv := service.NewUserServiceServer(memory.NewUserRepository())
+	mux := runtime.NewServeMux(
+		runtime.WithErrorHandler(gateway.ErrorHandler),
+		runtime.WithMiddlewares(gateway.HidePaths(regexp.MustCompile(`^/v1/admin/`))),
+	)
+	if err := user.RegisterUserServiceHandlerServer(context.Background(), mux, srv); err != nil {
+		t.Fatalf("register gateway: %v", err)
+	}
+	created, err := srv.CreateUser(context.Background(), &user.CreateUserRequest{
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		Gender:      "Female",
+		DateOfBirth: "1990-01-02",
+		Email:       "jane@example.com",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	for _, tt := range []struct {
+		method, path string
+		wantStatus   int
+	}{
+		{http.MethodDelete, "/v1/admin/user/" + created.GetId(), http.StatusNotFound},
+		{http.MethodGet, "/v1/user?email=jane@example.com", http.StatusOK},
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.wantStatus {
+			t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.wantStatus, rec.Body)
+		}
+	}
+	if _, err := srv.GetUser(context.Background(), &user.GetUserRequest{Identifier: &user.GetUserRequest_Email{Email: "jane@example.com"}}); err != nil {
+		t.Errorf("GetUser after hidden purge: %v", err)
+	}
+}
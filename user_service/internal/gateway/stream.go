@@ -0,0 +1,118 @@
+package gateway
+
+import (
+	"bytes"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// NDJSONContentType is the media type of newline delimited JSON streams.
+	NDJSONContentType = "application/x-ndjson"
+
+	// EventStreamContentType is the media type of Server-Sent Events.
+	EventStreamContentType = "text/event-stream"
+)
+
+// jsonMarshaler returns a marshaler configured like the gateway's default
+// JSON marshaler, so that streamed messages render as unary responses do.
+func jsonMarshaler() runtime.Marshaler {
+	return &runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		},
+	}
+}
+
+// NewNDJSONMarshaler returns an NDJSONMarshaler over the default JSON
+// marshaler.
+func NewNDJSONMarshaler() NDJSONMarshaler {
+	return NDJSONMarshaler{Marshaler: jsonMarshaler()}
+}
+
+// NewEventStreamMarshaler returns an EventStreamMarshaler over the default
+// JSON marshaler.
+func NewEventStreamMarshaler() EventStreamMarshaler {
+	return EventStreamMarshaler{Marshaler: jsonMarshaler()}
+}
+
+// NDJSONMarshaler writes server-streaming responses as newline delimited JSON,
+// one {"result": ...} or {"error": ...} object per line. It is selected by
+// requests that accept application/x-ndjson; streams requested without it use
+// the same format under the application/json content type.
+type NDJSONMarshaler struct {
+	runtime.Marshaler
+}
+
+// StreamContentType reports the NDJSON media type for streamed responses.
+func (m NDJSONMarshaler) StreamContentType(v interface{}) string {
+	return NDJSONContentType
+}
+
+// Delimiter separates the streamed objects.
+func (m NDJSONMarshaler) Delimiter() []byte {
+	return []byte("\n")
+}
+
+// EventStreamMarshaler writes server-streaming responses as Server-Sent
+// Events. Each message becomes a "message" event whose data is the JSON
+// {"result": ...} object and whose id is the message's cursor, so that
+// EventSource clients resume after the last event through Last-Event-ID.
+// Stream errors become "error" events. Unary responses are written as plain
+// JSON.
+type EventStreamMarshaler struct {
+	runtime.Marshaler
+}
+
+// ContentType reports the event stream media type for stream chunks,
+// including errors written before the first message.
+func (m EventStreamMarshaler) ContentType(v interface{}) string {
+	if isStreamChunk(v) {
+		return EventStreamContentType
+	}
+	return m.Marshaler.ContentType(v)
+}
+
+// StreamContentType reports the event stream media type.
+func (m EventStreamMarshaler) StreamContentType(v interface{}) string {
+	return EventStreamContentType
+}
+
+// Marshal frames stream chunks as events.
+func (m EventStreamMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.Marshaler.Marshal(v)
+	if err != nil || !isStreamChunk(v) {
+		return data, err
+	}
+
+	var buf bytes.Buffer
+	switch chunk := v.(type) {
+	case map[string]interface{}:
+		if c, ok := chunk["result"].(interface{ GetCursor() string }); ok && c.GetCursor() != "" {
+			buf.WriteString("id: " + c.GetCursor() + "\n")
+		}
+	default:
+		buf.WriteString("event: error\n")
+	}
+	buf.WriteString("data: ")
+	buf.Write(bytes.ReplaceAll(data, []byte("\n"), []byte("\ndata: ")))
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// Delimiter ends each event with a blank line.
+func (m EventStreamMarshaler) Delimiter() []byte {
+	return []byte("\n")
+}
+
+// isStreamChunk reports whether v is one of the {"result": ...} or
+// {"error": ...} wrappers runtime.ForwardResponseStream marshals.
+func isStreamChunk(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, map[string]proto.Message:
+		return true
+	}
+	return false
+}
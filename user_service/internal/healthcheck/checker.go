@@ -0,0 +1,112 @@
+// Package healthcheck reports the health of the user service through the
+// grpc.health.v1.Health service and the /healthz and /readyz endpoints of the
+// REST gateway.
+//
+// The overall server status ("") is SERVING while the gRPC server runs. The
+// status of every registered service follows a periodic probe of the
+// dependencies, such as Cassandra, that it needs to answer requests.
+package healthcheck
+
+import (
+	"context"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultProbeInterval is used by Run when given no interval.
+	defaultProbeInterval = 10 * time.Second
+
+	// defaultProbeTimeout is used by Run when given no timeout.
+	defaultProbeTimeout = 2 * time.Second
+)
+
+// Probe checks a dependency, returning an error while it is unavailable.
+type Probe func(ctx context.Context) error
+
+// Checker drives the per-service status of a gRPC health server from a probe.
+type Checker struct {
+	server   *health.Server
+	services []string
+	probe    Probe
+
+	mu      sync.Mutex
+	lastErr error
+	probed  bool
+}
+
+// NewChecker returns a checker for services. Until the first probe succeeds
+// they are NOT_SERVING; without a probe they are SERVING right away.
+func NewChecker(probe Probe, services ...string) *Checker {
+	c := &Checker{server: health.NewServer(), services: services, probe: probe}
+	status := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if probe == nil {
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	for _, s := range services {
+		c.server.SetServingStatus(s, status)
+	}
+	return c
+}
+
+// Server returns the health server to register on the gRPC server.
+func (c *Checker) Server() *health.Server {
+	return c.server
+}
+
+// Run probes every interval until ctx is cancelled, giving each probe up to
+// timeout.
+func (c *Checker) Run(ctx context.Context, interval, timeout time.Duration) {
+	if c.probe == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = defaultProbeInterval
+	}
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, timeout)
+		c.Check(probeCtx)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check probes once and updates the status of the services. Changes are
+// logged.
+func (c *Checker) Check(ctx context.Context) {
+	err := c.probe(ctx)
+
+	c.mu.Lock()
+	changed := !c.probed || (err == nil) != (c.lastErr == nil)
+	c.lastErr, c.probed = err, true
+	c.mu.Unlock()
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if err != nil {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	for _, s := range c.services {
+		c.server.SetServingStatus(s, status)
+	}
+	if changed {
+		if err != nil {
+			log.Printf("Health probe failed, services are %s: %v", status, err)
+		} else {
+			log.Printf("Health probe succeeded, services are %s", status)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testProbe fails with err and counts its calls.
+type testProbe struct {
+	mu    sync.Mutex
+	err   error
+	calls int
+}
+
+func (p *testProbe) probe(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	return p.err
+}
+
+func (p *testProbe) set(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+func (p *testProbe) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+func servingStatus(t *testing.T, c *Checker, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := c.Server().Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q): %v", service, err)
+	}
+	return resp.GetStatus()
+}
+
+func TestChecker(t *testing.T) {
+	ctx := context.Background()
+	p := &testProbe{}
+	c := NewChecker(p.probe, "users")
+	if got := servingStatus(t, c, "users"); got != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status before the first probe = %s, want NOT_SERVING", got)
+	}
+	if got := servingStatus(t, c, ""); got != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("server status = %s, want SERVING", got)
+	}
+
+	for _, tt := range []struct {
+		err  error
+		want grpc_health_v1.HealthCheckResponse_ServingStatus
+	}{
+		{nil, grpc_health_v1.HealthCheckResponse_SERVING},
+		{errors.New("cassandra unavailable"), grpc_health_v1.HealthCheckResponse_NOT_SERVING},
+		{nil, grpc_health_v1.HealthCheckResponse_SERVING},
+	} {
+		p.set(tt.err)
+		c.Check(ctx)
+		if got := servingStatus(t, c, "users"); got != tt.want {
+			t.Errorf("status after a probe returning %v = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+
+	if got := servingStatus(t, NewChecker(nil, "users"), "users"); got != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("status without a probe = %s, want SERVING", got)
+	}
+}
+
+func TestCheckerRun(t *testing.T) {
+	p := &testProbe{}
+	c := NewChecker(p.probe, "users")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Run(ctx, time.Millisecond, time.Second)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for p.count() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+	if got := p.count(); got < 3 {
+		t.Fatalf("probed %d times, want at least 3", got)
+	}
+	if got := servingStatus(t, c, "users"); got != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("status = %s, want SERVING", got)
+	}
+}
@@ -0,0 +1,73 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"log"
+	"net/http"
+	"time"
+)
+
+// checkTimeout bounds the health checks made by the HTTP handlers.
+const checkTimeout = 2 * time.Second
+
+// serverName labels the overall server status in a report.
+const serverName = "server"
+
+// report is the body of the /healthz and /readyz responses.
+type report struct {
+	Status   string            `json:"status"`
+	Services map[string]string `json:"services"`
+}
+
+// Register serves /healthz and /readyz on the gateway mux. Both ask the gRPC
+// server through client, so they fail while it cannot be reached. /healthz is
+// the liveness check and only needs the server to answer, so that it keeps
+// passing while the server drains; /readyz needs the server and every one of
+// services to be SERVING.
+func Register(mux *runtime.ServeMux, client grpc_health_v1.HealthClient, services ...string) error {
+	if err := mux.HandlePath(http.MethodGet, "/healthz", handler(client, false)); err != nil {
+		return err
+	}
+	return mux.HandlePath(http.MethodGet, "/readyz", handler(client, true, services...))
+}
+
+// handler reports the status of the server and services. It fails when a
+// check cannot be made, and with requireServing also when a status is not
+// SERVING.
+func handler(client grpc_health_v1.HealthClient, requireServing bool, services ...string) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+
+		rep := report{Status: grpc_health_v1.HealthCheckResponse_SERVING.String(), Services: map[string]string{}}
+		for _, s := range append([]string{""}, services...) {
+			status := grpc_health_v1.HealthCheckResponse_UNKNOWN.String()
+			resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: s})
+			if err == nil {
+				status = resp.GetStatus().String()
+			}
+			if err != nil || requireServing && status != grpc_health_v1.HealthCheckResponse_SERVING.String() {
+				rep.Status = grpc_health_v1.HealthCheckResponse_NOT_SERVING.String()
+			}
+			name := s
+			if name == "" {
+				name = serverName
+			}
+			rep.Services[name] = status
+		}
+
+		code := http.StatusOK
+		if rep.Status != grpc_health_v1.HealthCheckResponse_SERVING.String() {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(rep); err != nil {
+			log.Printf("Failed to write health report: %v", err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// localClient calls a health server in the same process, or fails with err.
+type localClient struct {
+	server *health.Server
+	err    error
+}
+
+func (c localClient) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest, _ ...grpc.CallOption) (*grpc_health_v1.HealthCheckResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.server.Check(ctx, req)
+}
+
+func (c localClient) Watch(ctx context.Context, req *grpc_health_v1.HealthCheckRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[grpc_health_v1.HealthCheckResponse], error) {
+	return nil, errors.New("not implemented")
+}
+
+func get(t *testing.T, client grpc_health_v1.HealthClient, path string) (int, report) {
+	t.Helper()
+	mux := runtime.NewServeMux()
+	if err := Register(mux, client, "users"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var rep report
+	if err := json.Unmarshal(rec.Body.Bytes(), &rep); err != nil {
+		t.Fatalf("GET %s: decode %q: %v", path, rec.Body, err)
+	}
+	return rec.Code, rep
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	server := health.NewServer()
+	server.SetServingStatus("users", grpc_health_v1.HealthCheckResponse_SERVING)
+	client := localClient{server: server}
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if code, rep := get(t, client, path); code != http.StatusOK || rep.Status != "SERVING" {
+			t.Errorf("GET %s = %d %+v, want 200 SERVING", path, code, rep)
+		}
+	}
+
+	// A service that is not serving fails readiness only.
+	server.SetServingStatus("users", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	if code, rep := get(t, client, "/readyz"); code != http.StatusServiceUnavailable || rep.Services["users"] != "NOT_SERVING" || rep.Services[serverName] != "SERVING" {
+		t.Errorf("GET /readyz = %d %+v, want 503 with users NOT_SERVING", code, rep)
+	}
+	if code, _ := get(t, client, "/healthz"); code != http.StatusOK {
+		t.Errorf("GET /healthz = %d, want 200 while the server answers", code)
+	}
+
+	// A server that cannot be reached fails both.
+	unreachable := localClient{err: errors.New("connection refused")}
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if code, rep := get(t, unreachable, path); code != http.StatusServiceUnavailable || rep.Services[serverName] != "UNKNOWN" {
+			t.Errorf("GET %s of an unreachable server = %d %+v, want 503 with the server UNKNOWN", path, code, rep)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"strings"
+	"user_service/internal/apierror"
+)
+
+// validator is implemented by every message generated by protoc-gen-validate.
+type validator interface {
+	ValidateAll() error
+}
+
+// fieldError is implemented by the <Message>ValidationError types generated
+// by protoc-gen-validate.
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// multiError is implemented by the <Message>MultiError types generated by
+// protoc-gen-validate.
+type multiError interface {
+	AllErrors() []error
+}
+
+// masked is implemented by requests that only change the fields selected by
+// their update_mask.
+type masked interface {
+	GetUpdateMask() *fieldmaskpb.FieldMask
+}
+
+// Validate is a unary server interceptor that checks every request carrying
+// protoc-gen-validate rules before it reaches the handler. A request that
+// violates its rules is rejected with codes.InvalidArgument and a
+// google.rpc.BadRequest detail listing every field violation, not just the
+// first one. Requests with an update_mask are left to their handler, since
+// the rules only apply to the fields the mask selects; the handler checks
+// those with ValidateMessage.
+func Validate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if _, ok := req.(masked); ok {
+		return handler(ctx, req)
+	}
+	if err := ValidateMessage(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// ValidateMessage checks m against its protoc-gen-validate rules and returns
+// the InvalidArgument error Validate would reject it with, or nil.
+func ValidateMessage(m interface{}) error {
+	v, ok := m.(validator)
+	if !ok {
+		return nil
+	}
+	if err := v.ValidateAll(); err != nil {
+		var md protoreflect.MessageDescriptor
+		if pm, ok := m.(proto.Message); ok {
+			md = pm.ProtoReflect().Descriptor()
+		}
+		return invalidArgument(md, err)
+	}
+	return nil
+}
+
+// ValidateStream is the stream server interceptor counterpart of Validate. It
+// checks every message received from the client.
+func ValidateStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ServerStream: ss})
+}
+
+// validatingStream validates the messages received on the wrapped stream.
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return ValidateMessage(m)
+}
+
+// invalidArgument converts a ValidateAll error into an InvalidArgument status
+// with a BadRequest detail.
+func invalidArgument(md protoreflect.MessageDescriptor, err error) error {
+	return apierror.InvalidArgument(fmt.Sprintf("Invalid request: %v", err), fieldViolations(md, "", err)...)
+}
+
+// fieldViolations flattens err into one violation per offending field.
+// Violations of embedded messages are reported with a dotted path such as
+// "users[0].email".
+func fieldViolations(md protoreflect.MessageDescriptor, prefix string, err error) []*errdetails.BadRequest_FieldViolation {
+	var multi multiError
+	if errors.As(err, &multi) {
+		var out []*errdetails.BadRequest_FieldViolation
+		for _, e := range multi.AllErrors() {
+			out = append(out, fieldViolations(md, prefix, e)...)
+		}
+		return out
+	}
+
+	var fe fieldError
+	if !errors.As(err, &fe) {
+		return []*errdetails.BadRequest_FieldViolation{{Field: prefix, Description: err.Error()}}
+	}
+
+	name, index, fd := fieldPath(md, fe.Field())
+	path := name + index
+	if prefix != "" {
+		path = prefix + "." + path
+	}
+
+	var nested fieldError
+	if cause := fe.Cause(); cause != nil && fd != nil && fd.Message() != nil &&
+		(errors.As(cause, &nested) || errors.As(cause, &multi)) {
+		return fieldViolations(fd.Message(), path, cause)
+	}
+	return []*errdetails.BadRequest_FieldViolation{{Field: path, Description: fe.Reason()}}
+}
+
+// fieldPath maps the Go field name reported by protoc-gen-validate, possibly
+// carrying a "[index]" suffix, back to the proto field name.
+func fieldPath(md protoreflect.MessageDescriptor, goName string) (string, string, protoreflect.FieldDescriptor) {
+	var index string
+	if i := strings.IndexByte(goName, '['); i >= 0 {
+		goName, index = goName[:i], goName[i:]
+	}
+	if md != nil {
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if camelCase(string(fd.Name())) == goName {
+				return string(fd.Name()), index, fd
+			}
+		}
+		oneofs := md.Oneofs()
+		for i := 0; i < oneofs.Len(); i++ {
+			if od := oneofs.Get(i); camelCase(string(od.Name())) == goName {
+				return string(od.Name()), index, nil
+			}
+		}
+	}
+	return goName, index, nil
+}
+
+// camelCase mirrors how protoc-gen-go derives Go field names from proto field
+// names, e.g. "date_of_birth" becomes "DateOfBirth".
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,127 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"testing"
+	"user_service/protogen/user"
+)
+
+// violations returns the fields of the BadRequest detail of err, sorted.
+func violations(t *testing.T, err error) []string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("error = %v, want InvalidArgument", err)
+	}
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func TestValidate(t *testing.T) {
+	valid := &user.CreateUserRequest{
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		Gender:      "Female",
+		DateOfBirth: "1990-01-02",
+		PhoneNumber: "+4915100000001",
+		Email:       "jane@example.com",
+	}
+	tests := []struct {
+		name       string
+		req        interface{}
+		wantFields []string
+	}{
+		{"valid", valid, nil},
+		{"not a validator", "plain value", nil},
+		{
+			name:       "every violation is reported",
+			req:        &user.CreateUserRequest{FirstName: "Jane", Gender: "bogus", DateOfBirth: "02.01.1990", PhoneNumber: "+4915100000001", Email: "jane"},
+			wantFields: []string{"date_of_birth", "email", "gender", "last_name"},
+		},
+		{
+			name:       "repeated field index",
+			req:        &user.WatchUsersRequest{UserIds: []string{"5b7f0c4e-3f8a-4d6e-9f61-1d2c3b4a5e6f", "not-a-uuid"}},
+			wantFields: []string{"user_ids[1]"},
+		},
+		{
+			name:       "oneof",
+			req:        &user.GetUserRequest{Identifier: &user.GetUserRequest_Email{Email: "jane"}},
+			wantFields: []string{"email"},
+		},
+		// UpdateUserRequest is validated by its handler, which knows which
+		// fields its update_mask selects.
+		{"request with update_mask", &user.UpdateUserRequest{Gender: "bogus"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			_, err := Validate(context.Background(), tt.req, &grpc.UnaryServerInfo{},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					called = true
+					return nil, nil
+				})
+			if tt.wantFields == nil {
+				if err != nil || !called {
+					t.Fatalf("Validate = %v, handler called %v; want the request passed on", err, called)
+				}
+				return
+			}
+			if called {
+				t.Error("handler was called for an invalid request")
+			}
+			if got := violations(t, err); fmt.Sprint(got) != fmt.Sprint(tt.wantFields) {
+				t.Errorf("violations = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+// recvStream is a grpc.ServerStream receiving a single message.
+type recvStream struct {
+	grpc.ServerStream
+	msg proto.Message
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.msg)
+	return nil
+}
+
+func TestValidateStream(t *testing.T) {
+	for _, tt := range []struct {
+		req        *user.WatchUsersRequest
+		wantFields []string
+	}{
+		{&user.WatchUsersRequest{UserIds: []string{"5b7f0c4e-3f8a-4d6e-9f61-1d2c3b4a5e6f"}}, nil},
+		{&user.WatchUsersRequest{UserIds: []string{"not-a-uuid"}}, []string{"user_ids[0]"}},
+	} {
+		err := ValidateStream(nil, &recvStream{msg: tt.req}, &grpc.StreamServerInfo{},
+			func(srv interface{}, ss grpc.ServerStream) error {
+				return ss.RecvMsg(&user.WatchUsersRequest{})
+			})
+		if tt.wantFields == nil {
+			if err != nil {
+				t.Errorf("ValidateStream(%v) = %v, want nil", tt.req, err)
+			}
+			continue
+		}
+		if got := violations(t, err); fmt.Sprint(got) != fmt.Sprint(tt.wantFields) {
+			t.Errorf("violations = %v, want %v", got, tt.wantFields)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package notify delivers messages, such as verification codes, to the email
+// address or phone number of a user.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Message is a single message to deliver.
+type Message struct {
+	// Channel is the kind of address To is: "EMAIL" or "PHONE".
+	Channel string    `json:"channel"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject,omitempty"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// Notifier delivers messages to users. Implementations must be safe for
+// concurrent use.
+type Notifier interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// LogNotifier writes messages to the standard logger instead of delivering
+// them. It is meant for local development.
+type LogNotifier struct{}
+
+var _ Notifier = LogNotifier{}
+
+// Send logs the message.
+func (LogNotifier) Send(ctx context.Context, m Message) error {
+	log.Printf("Sending %s message to %s: %s", m.Channel, m.To, m.Body)
+	return nil
+}
+
+// FileNotifier appends messages to a file, one JSON object per line, instead
+// of delivering them. It is meant for local development and tests that need to
+// read the messages back.
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+var _ Notifier = (*FileNotifier)(nil)
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+// Send appends the message to the file, creating it if needed.
+func (n *FileNotifier) Send(ctx context.Context, m Message) error {
+	line, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
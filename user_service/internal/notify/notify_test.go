@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.jsonl")
+	n := NewFileNotifier(path)
+	sent := []Message{
+		{Channel: "EMAIL", To: "jane@example.com", Subject: "Your verification code", Body: "Your verification code is 123456.", SentAt: time.Now().UTC()},
+		{Channel: "PHONE", To: "+4915100000001", Body: "Your verification code is 654321.", SentAt: time.Now().UTC()},
+	}
+	for _, m := range sent {
+		if err := n.Send(context.Background(), m); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open messages: %v", err)
+	}
+	defer f.Close()
+	var got []Message
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		var m Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatalf("decode %q: %v", scanner.Text(), err)
+		}
+		got = append(got, m)
+	}
+	if len(got) != len(sent) {
+		t.Fatalf("got %d messages, want %d", len(got), len(sent))
+	}
+	for i := range sent {
+		if got[i].To != sent[i].To || got[i].Body != sent[i].Body || !got[i].SentAt.Equal(sent[i].SentAt) {
+			t.Errorf("message %d = %+v, want %+v", i, got[i], sent[i])
+		}
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("messages file mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"embed"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// swaggerUI holds the scripts and styles of the swagger-ui-dist release named
+// in swaggerui/VERSION, served from the binary so that /docs loads no
+// third-party code at runtime. To move to another release, run
+// swaggerui/update.sh with its version: it downloads the files from the npm
+// registry, checks them against the registry's shasum and updates VERSION.
+//
+//go:embed swaggerui/swagger-ui-bundle.js swaggerui/swagger-ui.css swaggerui/LICENSE
+var swaggerUI embed.FS
+
+// embeddedAssets is the path the embedded Swagger UI files are served under.
+const embeddedAssets = "/docs/assets"
+
+var docsPage = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>User Service API</title>
+  <link rel="stylesheet" href="{{.Assets}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.Assets}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "{{.Spec}}", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`))
+
+// Register serves the Swagger 2.0 document at /openapi.json, the OpenAPI 3.0
+// document at /openapi.v3.json and the Swagger UI at /docs on the gateway mux. The UI uses the embedded swagger-ui-dist files unless
+// swaggerUIAssets is the base URL of another release.
+func Register(mux *runtime.ServeMux, swaggerUIAssets string) error {
+	if swaggerUIAssets == "" {
+		swaggerUIAssets = embeddedAssets
+	}
+	swaggerUIAssets = strings.TrimSuffix(swaggerUIAssets, "/")
+
+	if err := mux.HandlePath(http.MethodGet, "/openapi.json", serveSpec(specV2)); err != nil {
+		return err
+	}
+	if err := mux.HandlePath(http.MethodGet, "/openapi.v3.json", serveSpec(specV3)); err != nil {
+		return err
+	}
+	assets, err := fs.Sub(swaggerUI, "swaggerui")
+	if err != nil {
+		return err
+	}
+	files := http.StripPrefix(embeddedAssets, http.FileServer(http.FS(assets)))
+	if err := mux.HandlePath(http.MethodGet, embeddedAssets+"/{file}", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		files.ServeHTTP(w, r)
+	}); err != nil {
+		return err
+	}
+	return mux.HandlePath(http.MethodGet, "/docs", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		data := struct{ Assets, Spec string }{swaggerUIAssets, "/openapi.json"}
+		if err := docsPage.Execute(w, data); err != nil {
+			log.Printf("Failed to render API docs: %v", err)
+		}
+	})
+}
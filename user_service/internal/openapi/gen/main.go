@@ -0,0 +1,33 @@
+// Command gen writes the OpenAPI documents embedded by package openapi:
+// openapi.v2.json, the user.swagger.json generated by protoc-gen-openapiv2
+// with the validation constraints added, and openapi.v3.json, the same
+// document converted to OpenAPI 3.0. It is run by go generate in the openapi
+// package directory.
+package main
+
+import (
+	"log"
+	"os"
+	"user_service/internal/openapi/spec"
+	"user_service/protogen/user"
+)
+
+func main() {
+	raw, err := os.ReadFile("user.swagger.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	v2, err := spec.V2(raw, user.File_user_proto)
+	if err != nil {
+		log.Fatal(err)
+	}
+	v3, err := spec.V3(v2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, doc := range map[string][]byte{"openapi.v2.json": v2, "openapi.v3.json": v3} {
+		if err := os.WriteFile(name, append(doc, '\n'), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
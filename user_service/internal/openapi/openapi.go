@@ -0,0 +1,42 @@
+// Package openapi serves the OpenAPI documents of the REST gateway and a
+// Swagger UI to browse them.
+//
+// The documents are generated by go generate: protoc-gen-openapiv2 writes
+// user.swagger.json from the google.api.http annotations of user.proto, and
+// ./gen adds the protoc-gen-validate rules as schema constraints and writes
+// the result as openapi.v2.json and, converted to OpenAPI 3.0, as
+// openapi.v3.json. Both are embedded and served as they are on disk.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:generate protoc -I ../../protogen/user -I ../.. --openapiv2_out=. --openapiv2_opt=openapi_naming_strategy=simple,disable_default_errors=true user.proto
+//go:generate go run ./gen
+
+var (
+	//go:embed openapi.v2.json
+	specV2 []byte
+
+	//go:embed openapi.v3.json
+	specV3 []byte
+)
+
+// SpecV2 returns the Swagger 2.0 document served at /openapi.json.
+func SpecV2() []byte {
+	return specV2
+}
+
+// SpecV3 returns the OpenAPI 3.0 document served at /openapi.v3.json.
+func SpecV3() []byte {
+	return specV3
+}
+
+func serveSpec(spec []byte) func(http.ResponseWriter, *http.Request, map[string]string) {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	}
+}
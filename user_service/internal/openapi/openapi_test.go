@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"user_service/internal/openapi/spec"
+	"user_service/protogen/user"
+)
+
+// TestSpecsUpToDate fails when user.proto or the spec package changed without
+// go generate being run, so that the documents on disk are those served.
+func TestSpecsUpToDate(t *testing.T) {
+	raw, err := os.ReadFile("user.swagger.json")
+	if err != nil {
+		t.Fatalf("read generated document: %v", err)
+	}
+	v2, err := spec.V2(raw, user.File_user_proto)
+	if err != nil {
+		t.Fatalf("V2: %v", err)
+	}
+	v3, err := spec.V3(v2)
+	if err != nil {
+		t.Fatalf("V3: %v", err)
+	}
+	if !bytes.Equal(append(v2, '\n'), SpecV2()) || !bytes.Equal(append(v3, '\n'), SpecV3()) {
+		t.Error("openapi.v2.json or openapi.v3.json is out of date, run go generate ./internal/openapi")
+	}
+}
+
+func TestSpecV2(t *testing.T) {
+	var doc struct {
+		Paths map[string]map[string]struct {
+			OperationID string                     `json:"operationId"`
+			Responses   map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+		Definitions map[string]struct {
+			Required []string `json:"required"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(SpecV2(), &doc); err != nil {
+		t.Fatalf("parse spec: %v", err)
+	}
+
+	// Every operation, including additional bindings such as the PATCH of
+	// UpdateUser, is matched to its method.
+	for path, item := range doc.Paths {
+		for verb, op := range item {
+			if _, ok := op.Responses["default"]; !ok {
+				t.Errorf("%s %s (%s) has no default problem response", verb, path, op.OperationID)
+			}
+		}
+	}
+	if _, ok := doc.Paths["/v1/user/{id}"]["patch"]; !ok {
+		t.Fatal("PATCH /v1/user/{id} is missing")
+	}
+
+	if got := doc.Definitions["CreateUserRequest"].Required; len(got) == 0 {
+		t.Errorf("CreateUserRequest lists no required fields")
+	}
+}
+
+func TestSpecV3(t *testing.T) {
+	type mediaTypes map[string]struct {
+		Schema struct {
+			Ref string `json:"$ref"`
+		} `json:"schema"`
+	}
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			Parameters []struct {
+				Name   string                 `json:"name"`
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"parameters"`
+			RequestBody *struct {
+				Content mediaTypes `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]struct {
+				Content mediaTypes `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required []string `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(SpecV3(), &doc); err != nil {
+		t.Fatalf("parse spec: %v", err)
+	}
+	if doc.OpenAPI != "3.0.3" {
+		t.Errorf("openapi = %q, want 3.0.3", doc.OpenAPI)
+	}
+	if strings.Contains(string(SpecV3()), "#/definitions/") {
+		t.Error("document still refers to #/definitions/")
+	}
+
+	create := doc.Paths["/v1/user"]["post"]
+	if create.RequestBody == nil || create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/CreateUserRequest" {
+		t.Errorf("POST /v1/user request body = %+v, want a CreateUserRequest", create.RequestBody)
+	}
+	if got := create.Responses["default"].Content["application/problem+json"].Schema.Ref; got != "#/components/schemas/Problem" {
+		t.Errorf("POST /v1/user error response schema = %q, want the Problem schema", got)
+	}
+	if got := doc.Components.Schemas["CreateUserRequest"].Required; len(got) == 0 {
+		t.Errorf("CreateUserRequest lists no required fields")
+	}
+	for _, p := range doc.Paths["/v1/users"]["get"].Parameters {
+		if p.Schema["type"] == nil {
+			t.Errorf("GET /v1/users parameter %s has no schema type", p.Name)
+		}
+	}
+}
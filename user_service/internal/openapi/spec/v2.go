@@ -0,0 +1,335 @@
+// Package spec builds the OpenAPI documents of the REST gateway.
+//
+// The Swagger 2.0 document is generated from the google.api.http annotations
+// of user.proto by protoc-gen-openapiv2. That generator does not understand
+// protoc-gen-validate rules, so V2 adds them as schema constraints from the
+// proto descriptors, together with the problem details returned for every
+// error. V3 converts the result to OpenAPI 3.0.
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// object is a JSON object of the document.
+type object = map[string]interface{}
+
+// V2 adds the validation rules of the messages in file to the document
+// generated by protoc-gen-openapiv2. Definitions are matched to messages by
+// name, request bodies and parameters to the input message of the method
+// bound to the operation's HTTP method and path.
+func V2(raw []byte, file protoreflect.FileDescriptor) ([]byte, error) {
+	var doc object
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("parse generated OpenAPI document: %w", err)
+	}
+	normalizeNewlines(doc)
+	doc["info"] = object{"title": "User Service API", "version": "v1"}
+
+	definitions, _ := doc["definitions"].(object)
+	for name, def := range definitions {
+		if md := file.Messages().ByName(protoreflect.Name(name)); md != nil {
+			constrainSchema(def.(object), md)
+		}
+	}
+	definitions["Problem"] = problemSchema
+
+	methods := httpBindings(file)
+	paths, _ := doc["paths"].(object)
+	for path, item := range paths {
+		for verb, v := range item.(object) {
+			op, _ := v.(object)
+			method := methods[newBinding(verb, path)]
+			if method == nil {
+				continue
+			}
+			for _, p := range parameters(op) {
+				if p["in"] == "body" {
+					schema, _ := p["schema"].(object)
+					ref, _ := schema["$ref"].(string)
+					if def, ok := definitions[strings.TrimPrefix(ref, "#/definitions/")].(object); ok {
+						constrainSchema(def, method.Input())
+					}
+					continue
+				}
+				name, _ := p["name"].(string)
+				if fd := method.Input().Fields().ByJSONName(name); fd != nil {
+					constrain(p, fd, fieldRules(fd))
+				}
+			}
+
+			op["responses"].(object)["default"] = object{
+				"description": "An error, returned as application/problem+json.",
+				"schema":      object{"$ref": "#/definitions/Problem"},
+			}
+			if method.IsStreamingServer() {
+				op["produces"] = []string{"application/json", "application/x-ndjson", "text/event-stream"}
+			}
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// binding is an HTTP method and path template of a google.api.http rule.
+// Variables are blanked, as the document renames them to their JSON names.
+type binding struct {
+	method, path string
+}
+
+var pathVariable = regexp.MustCompile(`\{[^}]*\}`)
+
+func newBinding(method, path string) binding {
+	return binding{strings.ToUpper(method), pathVariable.ReplaceAllString(path, "{}")}
+}
+
+// httpBindings maps the HTTP rules of the methods in file, including their
+// additional bindings, to the methods.
+func httpBindings(file protoreflect.FileDescriptor) map[binding]protoreflect.MethodDescriptor {
+	bindings := make(map[binding]protoreflect.MethodDescriptor)
+	services := file.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			md := methods.Get(j)
+			rule, _ := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+			if rule == nil {
+				continue
+			}
+			for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+				if b, ok := ruleBinding(r); ok {
+					bindings[b] = md
+				}
+			}
+		}
+	}
+	return bindings
+}
+
+func ruleBinding(r *annotations.HttpRule) (binding, bool) {
+	switch p := r.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return newBinding(http.MethodGet, p.Get), true
+	case *annotations.HttpRule_Put:
+		return newBinding(http.MethodPut, p.Put), true
+	case *annotations.HttpRule_Post:
+		return newBinding(http.MethodPost, p.Post), true
+	case *annotations.HttpRule_Delete:
+		return newBinding(http.MethodDelete, p.Delete), true
+	case *annotations.HttpRule_Patch:
+		return newBinding(http.MethodPatch, p.Patch), true
+	case *annotations.HttpRule_Custom:
+		return newBinding(p.Custom.GetKind(), p.Custom.GetPath()), true
+	}
+	return binding{}, false
+}
+
+// normalizeNewlines replaces the CRLF line breaks that descriptions copied
+// from the comments of user.proto keep in the generated document.
+func normalizeNewlines(v interface{}) {
+	switch v := v.(type) {
+	case object:
+		for k, e := range v {
+			if s, ok := e.(string); ok {
+				v[k] = strings.ReplaceAll(s, "\r\n", "\n")
+				continue
+			}
+			normalizeNewlines(e)
+		}
+	case []interface{}:
+		for _, e := range v {
+			normalizeNewlines(e)
+		}
+	}
+}
+
+func parameters(op object) []object {
+	list, _ := op["parameters"].([]interface{})
+	params := make([]object, 0, len(list))
+	for _, p := range list {
+		params = append(params, p.(object))
+	}
+	return params
+}
+
+// constrainSchema adds the rules of md's fields to the properties of def and
+// lists the fields that must be set as required.
+func constrainSchema(def object, md protoreflect.MessageDescriptor) {
+	props, _ := def["properties"].(object)
+	var required []string
+	for name, prop := range props {
+		fd := md.Fields().ByJSONName(name)
+		if fd == nil {
+			continue
+		}
+		if constrain(prop.(object), fd, fieldRules(fd)) {
+			required = append(required, name)
+		}
+	}
+	if len(required) > 0 {
+		def["required"] = mergeRequired(def["required"], required)
+	}
+}
+
+func fieldRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	if !proto.HasExtension(fd.Options(), validate.E_Rules) {
+		return nil
+	}
+	r, _ := proto.GetExtension(fd.Options(), validate.E_Rules).(*validate.FieldRules)
+	return r
+}
+
+// constrain adds the rules to the schema or parameter s of field fd and
+// reports whether they require the field to be set. Rules without a schema
+// equivalent, such as gt_now, are left to the field description.
+func constrain(s object, fd protoreflect.FieldDescriptor, r *validate.FieldRules) bool {
+	switch {
+	case r == nil:
+		return false
+	case r.GetString_() != nil:
+		return constrainString(s, r.GetString_())
+	case r.GetInt32() != nil:
+		if i := r.GetInt32(); i.Gte != nil {
+			s["minimum"] = i.GetGte()
+		}
+		if i := r.GetInt32(); i.Lte != nil {
+			s["maximum"] = i.GetLte()
+		}
+	case r.GetEnum() != nil:
+		constrainEnum(s, fd.Enum(), r.GetEnum())
+	case r.GetRepeated() != nil:
+		rep := r.GetRepeated()
+		if rep.MinItems != nil {
+			s["minItems"] = rep.GetMinItems()
+		}
+		if rep.MaxItems != nil {
+			s["maxItems"] = rep.GetMaxItems()
+		}
+		if items, ok := s["items"].(object); ok {
+			constrain(items, fd, rep.GetItems())
+		}
+		return rep.GetMinItems() > 0
+	case r.GetTimestamp() != nil:
+		return r.GetTimestamp().GetRequired()
+	case r.GetMessage() != nil:
+		return r.GetMessage().GetRequired()
+	}
+	// int64 values are strings in JSON, which have no numeric bounds.
+	return false
+}
+
+func constrainString(s object, r *validate.StringRules) bool {
+	if r.Len != nil {
+		s["minLength"], s["maxLength"] = r.GetLen(), r.GetLen()
+	}
+	if r.MinLen != nil {
+		s["minLength"] = r.GetMinLen()
+	}
+	if r.MaxLen != nil {
+		s["maxLength"] = r.GetMaxLen()
+	}
+	if r.Pattern != nil {
+		s["pattern"] = r.GetPattern()
+	}
+	if len(r.In) > 0 {
+		s["enum"] = r.In
+	}
+	switch {
+	case r.GetEmail():
+		s["format"] = "email"
+	case r.GetUuid():
+		s["format"] = "uuid"
+	case r.GetHostname():
+		s["format"] = "hostname"
+	case r.GetUri():
+		s["format"] = "uri"
+	}
+	return r.GetMinLen() > 0 && !r.GetIgnoreEmpty()
+}
+
+// constrainEnum narrows the accepted enum names to those allowed by in and
+// not_in. A reference to the shared enum definition is replaced by an inline
+// schema, as Swagger 2.0 ignores the siblings of $ref.
+func constrainEnum(s object, ed protoreflect.EnumDescriptor, r *validate.EnumRules) {
+	if ed == nil || len(r.In) == 0 && len(r.NotIn) == 0 {
+		return
+	}
+	allowed := make(map[int32]bool)
+	for _, n := range r.In {
+		allowed[n] = true
+	}
+	excluded := make(map[int32]bool)
+	for _, n := range r.NotIn {
+		excluded[n] = true
+	}
+
+	var names []string
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+		if len(r.In) > 0 && !allowed[int32(v.Number())] || excluded[int32(v.Number())] {
+			continue
+		}
+		names = append(names, string(v.Name()))
+	}
+	delete(s, "$ref")
+	delete(s, "default")
+	s["type"], s["enum"] = "string", names
+}
+
+func mergeRequired(existing interface{}, required []string) []string {
+	var merged []string
+	seen := make(map[string]bool)
+	list, _ := existing.([]interface{})
+	for _, name := range list {
+		if n, ok := name.(string); ok && !seen[n] {
+			seen[n] = true
+			merged = append(merged, n)
+		}
+	}
+	for _, n := range required {
+		if !seen[n] {
+			seen[n] = true
+			merged = append(merged, n)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// problemSchema describes the RFC 7807 problem details written by
+// gateway.ErrorHandler.
+var problemSchema = object{
+	"type":        "object",
+	"description": "RFC 7807 problem details. code, reason, metadata and invalid-params carry the gRPC status code and error details.",
+	"properties": object{
+		"type":     object{"type": "string", "description": "urn:user_service:<reason>, or about:blank"},
+		"title":    object{"type": "string"},
+		"status":   object{"type": "integer", "format": "int32"},
+		"detail":   object{"type": "string"},
+		"instance": object{"type": "string"},
+		"code":     object{"type": "string", "description": "gRPC status code, e.g. NotFound"},
+		"reason":   object{"type": "string", "description": "Stable reason code, e.g. USER_NOT_FOUND"},
+		"metadata": object{"type": "object", "additionalProperties": object{"type": "string"}},
+		"invalid-params": object{
+			"type": "array",
+			"items": object{
+				"type": "object",
+				"properties": object{
+					"name":   object{"type": "string"},
+					"reason": object{"type": "string"},
+				},
+			},
+		},
+	},
+	"required": []string{"type", "title", "status", "code"},
+}
@@ -0,0 +1,150 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// parameterSchemaKeys are the keys of a Swagger 2.0 parameter that OpenAPI 3.0
+// moves into the parameter's schema.
+var parameterSchemaKeys = []string{
+	"type", "format", "items", "enum", "default", "pattern",
+	"minLength", "maxLength", "minimum", "maximum", "minItems", "maxItems",
+}
+
+// V3 converts a Swagger 2.0 document produced by V2 to OpenAPI 3.0. It covers
+// what protoc-gen-openapiv2 emits: path, query and body parameters, response
+// schemas and definitions, whose references are rewritten to
+// components/schemas.
+func V3(v2 []byte) ([]byte, error) {
+	var doc object
+	if err := json.Unmarshal(v2, &doc); err != nil {
+		return nil, fmt.Errorf("parse OpenAPI v2 document: %w", err)
+	}
+	rewriteRefs(doc)
+
+	consumes, produces := mediaTypes(doc["consumes"]), mediaTypes(doc["produces"])
+	paths, _ := doc["paths"].(object)
+	for _, item := range paths {
+		for _, v := range item.(object) {
+			convertOperation(v.(object), consumes, produces)
+		}
+	}
+
+	out := object{
+		"openapi":    "3.0.3",
+		"info":       doc["info"],
+		"paths":      paths,
+		"components": object{"schemas": doc["definitions"]},
+	}
+	if tags, ok := doc["tags"]; ok {
+		out["tags"] = tags
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// convertOperation moves the body parameter of op to its requestBody, the
+// type of the other parameters to their schema, and the response schemas to
+// their content.
+func convertOperation(op object, consumes, produces []string) {
+	if c := mediaTypes(op["consumes"]); c != nil {
+		consumes = c
+	}
+	if p := mediaTypes(op["produces"]); p != nil {
+		produces = p
+	}
+	delete(op, "consumes")
+	delete(op, "produces")
+
+	var params []interface{}
+	for _, p := range parameters(op) {
+		if p["in"] == "body" {
+			body := object{"content": content(consumes, p["schema"])}
+			if desc, ok := p["description"]; ok {
+				body["description"] = desc
+			}
+			if required, ok := p["required"]; ok {
+				body["required"] = required
+			}
+			op["requestBody"] = body
+			continue
+		}
+		schema := object{}
+		for _, key := range parameterSchemaKeys {
+			if v, ok := p[key]; ok {
+				schema[key] = v
+				delete(p, key)
+			}
+		}
+		if p["collectionFormat"] == "multi" {
+			p["style"], p["explode"] = "form", true
+		}
+		delete(p, "collectionFormat")
+		p["schema"] = schema
+		params = append(params, p)
+	}
+	if params != nil {
+		op["parameters"] = params
+	} else {
+		delete(op, "parameters")
+	}
+
+	responses, _ := op["responses"].(object)
+	for code, r := range responses {
+		resp := r.(object)
+		schema, ok := resp["schema"]
+		if !ok {
+			continue
+		}
+		// V2 documents every error as the default response, which Swagger
+		// 2.0 cannot give a media type of its own.
+		if code == "default" {
+			resp["content"] = content([]string{"application/problem+json"}, schema)
+		} else {
+			resp["content"] = content(produces, schema)
+		}
+		delete(resp, "schema")
+	}
+}
+
+func content(types []string, schema interface{}) object {
+	c := object{}
+	for _, t := range types {
+		c[t] = object{"schema": schema}
+	}
+	return c
+}
+
+func mediaTypes(v interface{}) []string {
+	var types []string
+	switch list := v.(type) {
+	case []interface{}:
+		for _, t := range list {
+			if s, ok := t.(string); ok {
+				types = append(types, s)
+			}
+		}
+	case []string:
+		types = list
+	}
+	return types
+}
+
+// rewriteRefs points the references to definitions at components/schemas.
+func rewriteRefs(v interface{}) {
+	switch v := v.(type) {
+	case object:
+		for k, e := range v {
+			if ref, ok := e.(string); ok && k == "$ref" {
+				v[k] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
+				continue
+			}
+			rewriteRefs(e)
+		}
+	case []interface{}:
+		for _, e := range v {
+			rewriteRefs(e)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+#!/bin/sh
+# Replaces the Swagger UI files embedded by package openapi with those of a
+# swagger-ui-dist release from the npm registry, after checking the tarball
+# against the shasum the registry publishes for it, and records the release
+# in VERSION.
+#
+# Usage: sh internal/openapi/swaggerui/update.sh 5.18.2
+set -eu
+
+version=${1:?usage: update.sh <swagger-ui-dist version>}
+dir=$(cd "$(dirname "$0")" && pwd)
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+registry=https://registry.npmjs.org/swagger-ui-dist
+curl -fsSL "$registry/$version" -o "$tmp/package.json"
+curl -fsSL "$registry/-/swagger-ui-dist-$version.tgz" -o "$tmp/dist.tgz"
+
+expected=$(sed -n 's/.*"shasum": *"\([0-9a-f]*\)".*/\1/p' "$tmp/package.json")
+actual=$(sha1sum "$tmp/dist.tgz" | cut -d' ' -f1)
+if [ -z "$expected" ] || [ "$expected" != "$actual" ]; then
+	echo "swagger-ui-dist $version: shasum $actual does not match the registry's \"$expected\"" >&2
+	exit 1
+fi
+
+tar -xzf "$tmp/dist.tgz" -C "$tmp" package/swagger-ui-bundle.js package/swagger-ui.css package/LICENSE
+cp "$tmp/package/swagger-ui-bundle.js" "$tmp/package/swagger-ui.css" "$tmp/package/LICENSE" "$dir/"
+echo "$version" >"$dir/VERSION"
+echo "Updated the embedded Swagger UI to swagger-ui-dist $version"
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// FieldChange is the before and after value of a single user field.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// AuditEvent records a single mutation of a user.
+type AuditEvent struct {
+	UserID string
+	// RPC is the name of the method that made the change, e.g. "UpdateUser".
+	RPC        string
+	Actor      string
+	RequestID  string
+	Changes    []FieldChange
+	OccurredAt time.Time
+}
+
+// AuditQuery selects and paginates the events returned by AuditStore.List.
+type AuditQuery struct {
+	UserID string
+	// From and To bound OccurredAt inclusively; zero leaves the bound open.
+	From time.Time
+	To   time.Time
+	// PageSize is the maximum number of events to return.
+	PageSize int
+	// PageToken is an opaque cursor returned by a previous List call.
+	PageToken string
+}
+
+// AuditStore keeps the append-only audit log of user mutations.
+type AuditStore interface {
+	// Append records an event. Events are never updated or removed.
+	Append(ctx context.Context, e *AuditEvent) error
+
+	// List returns a page of the events matching q, newest first, and the
+	// token of the next page, which is empty once there are no more results.
+	List(ctx context.Context, q AuditQuery) ([]*AuditEvent, string, error)
+}
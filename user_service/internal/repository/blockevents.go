@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// BlockAction is the kind of change recorded by a BlockEvent.
+type BlockAction string
+
+const (
+	BlockActionBlocked   BlockAction = "BLOCKED"
+	BlockActionUnblocked BlockAction = "UNBLOCKED"
+)
+
+// BlockEvent records a single block or unblock of a user.
+type BlockEvent struct {
+	UserID string
+	Action BlockAction
+	Reason string
+	Actor  string
+	// ExpiresAt is the expiry of the block for BlockActionBlocked events and
+	// zero otherwise.
+	ExpiresAt  time.Time
+	OccurredAt time.Time
+}
+
+// BlockEventStore keeps the append-only block history of users.
+type BlockEventStore interface {
+	// Append records an event. Events are never updated or removed.
+	Append(ctx context.Context, e *BlockEvent) error
+
+	// List returns a page of the events of a user, newest first, and the
+	// token of the next page, which is empty once there are no more results.
+	List(ctx context.Context, userID string, pageSize int, pageToken string) ([]*BlockEvent, string, error)
+}
@@ -0,0 +1,83 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/gocql/gocql"
+	"user_service/internal/repository"
+)
+
+// AuditStore keeps the audit log in the audit_events table, one partition per
+// user clustered by a time UUID in descending order. The field changes are
+// stored as a JSON document.
+type AuditStore struct {
+	session *gocql.Session
+}
+
+var _ repository.AuditStore = (*AuditStore)(nil)
+
+func NewAuditStore(session *gocql.Session) *AuditStore {
+	return &AuditStore{session: session}
+}
+
+// Append inserts the event under a time UUID derived from its OccurredAt.
+func (s *AuditStore) Append(ctx context.Context, e *repository.AuditEvent) error {
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO audit_events (user_id, event_id, rpc, actor, request_id, changes, occurred_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	return s.session.Query(query, e.UserID, gocql.UUIDFromTime(e.OccurredAt), e.RPC, nullString(e.Actor),
+		nullString(e.RequestID), string(changes), e.OccurredAt).WithContext(ctx).Exec()
+}
+
+// List reads one page of the user's partition, restricted to the time range
+// through the time UUID clustering column. As with UserRepository.List the
+// page token is the driver's paging state, base64 encoded.
+func (s *AuditStore) List(ctx context.Context, q repository.AuditQuery) ([]*repository.AuditEvent, string, error) {
+	var state []byte
+	if q.PageToken != "" {
+		var err error
+		if state, err = base64.RawURLEncoding.DecodeString(q.PageToken); err != nil {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+	}
+
+	query := `SELECT rpc, actor, request_id, changes, occurred_at FROM audit_events WHERE user_id = ?`
+	args := []interface{}{q.UserID}
+	if !q.From.IsZero() {
+		query += ` AND event_id >= minTimeuuid(?)`
+		args = append(args, q.From)
+	}
+	if !q.To.IsZero() {
+		query += ` AND event_id <= maxTimeuuid(?)`
+		args = append(args, q.To)
+	}
+
+	iter := s.session.Query(query, args...).WithContext(ctx).PageSize(q.PageSize).PageState(state).Iter()
+	var (
+		events  []*repository.AuditEvent
+		e       repository.AuditEvent
+		changes string
+	)
+	for iter.Scan(&e.RPC, &e.Actor, &e.RequestID, &changes, &e.OccurredAt) {
+		row := e
+		row.UserID, row.Changes = q.UserID, nil
+		if err := json.Unmarshal([]byte(changes), &row.Changes); err != nil {
+			iter.Close()
+			return nil, "", err
+		}
+		events = append(events, &row)
+	}
+	next := iter.PageState()
+	if err := iter.Close(); err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(next) > 0 {
+		nextPageToken = base64.RawURLEncoding.EncodeToString(next)
+	}
+	return events, nextPageToken, nil
+}
@@ -0,0 +1,62 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/gocql/gocql"
+	"user_service/internal/repository"
+)
+
+// BlockEventStore keeps block history in the user_block_events table, one
+// partition per user clustered by a time UUID in descending order.
+type BlockEventStore struct {
+	session *gocql.Session
+}
+
+var _ repository.BlockEventStore = (*BlockEventStore)(nil)
+
+func NewBlockEventStore(session *gocql.Session) *BlockEventStore {
+	return &BlockEventStore{session: session}
+}
+
+// Append inserts the event under a time UUID derived from its OccurredAt.
+func (s *BlockEventStore) Append(ctx context.Context, e *repository.BlockEvent) error {
+	query := `INSERT INTO user_block_events (user_id, event_id, action, reason, actor, expires_at, occurred_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	return s.session.Query(query, e.UserID, gocql.UUIDFromTime(e.OccurredAt), string(e.Action),
+		nullString(e.Reason), nullString(e.Actor), nullTime(e.ExpiresAt), e.OccurredAt).WithContext(ctx).Exec()
+}
+
+// List reads one page of the user's partition. As with UserRepository.List the
+// page token is the driver's paging state, base64 encoded.
+func (s *BlockEventStore) List(ctx context.Context, userID string, pageSize int, pageToken string) ([]*repository.BlockEvent, string, error) {
+	var state []byte
+	if pageToken != "" {
+		var err error
+		if state, err = base64.RawURLEncoding.DecodeString(pageToken); err != nil {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+	}
+
+	query := `SELECT action, reason, actor, expires_at, occurred_at FROM user_block_events WHERE user_id = ?`
+	iter := s.session.Query(query, userID).WithContext(ctx).PageSize(pageSize).PageState(state).Iter()
+	var (
+		events []*repository.BlockEvent
+		action string
+		e      repository.BlockEvent
+	)
+	for iter.Scan(&action, &e.Reason, &e.Actor, &e.ExpiresAt, &e.OccurredAt) {
+		row := e
+		row.UserID, row.Action = userID, repository.BlockAction(action)
+		events = append(events, &row)
+	}
+	next := iter.PageState()
+	if err := iter.Close(); err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(next) > 0 {
+		nextPageToken = base64.RawURLEncoding.EncodeToString(next)
+	}
+	return events, nextPageToken, nil
+}
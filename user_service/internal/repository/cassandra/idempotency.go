@@ -0,0 +1,60 @@
+package cassandra
+
+import (
+	"context"
+	"github.com/gocql/gocql"
+	"time"
+	"user_service/internal/repository"
+)
+
+// IdempotencyStore keeps idempotency records in the idempotency_keys table,
+// relying on Cassandra TTLs to expire them.
+type IdempotencyStore struct {
+	session *gocql.Session
+}
+
+var _ repository.IdempotencyStore = (*IdempotencyStore)(nil)
+
+func NewIdempotencyStore(session *gocql.Session) *IdempotencyStore {
+	return &IdempotencyStore{session: session}
+}
+
+// Begin claims the key with a lightweight transaction that expires with the
+// lease.
+func (s *IdempotencyStore) Begin(ctx context.Context, key, fingerprint string, lease time.Duration) (*repository.IdempotencyRecord, error) {
+	query := `INSERT INTO idempotency_keys (key, fingerprint, created_at) VALUES (?, ?, ?) IF NOT EXISTS USING TTL ?`
+	existing := map[string]interface{}{}
+	applied, err := s.session.Query(query, key, fingerprint, now(), ttlSeconds(lease)).WithContext(ctx).MapScanCAS(existing)
+	if err != nil {
+		return nil, err
+	}
+	if applied {
+		return nil, nil
+	}
+
+	rec := &repository.IdempotencyRecord{}
+	rec.Fingerprint, _ = existing["fingerprint"].(string)
+	if response, ok := existing["response"].([]byte); ok && len(response) > 0 {
+		rec.Response = response
+	}
+	return rec, nil
+}
+
+// Complete rewrites the claimed row with the response, so that all of its
+// columns outlive the lease and expire together after ttl.
+func (s *IdempotencyStore) Complete(ctx context.Context, key, fingerprint string, response []byte, ttl time.Duration) error {
+	query := `INSERT INTO idempotency_keys (key, fingerprint, response, created_at) VALUES (?, ?, ?, ?) USING TTL ?`
+	return s.session.Query(query, key, fingerprint, response, now(), ttlSeconds(ttl)).WithContext(ctx).Exec()
+}
+
+// Abandon deletes the claimed key.
+func (s *IdempotencyStore) Abandon(ctx context.Context, key string) error {
+	return s.session.Query(`DELETE FROM idempotency_keys WHERE key = ?`, key).WithContext(ctx).Exec()
+}
+
+func ttlSeconds(ttl time.Duration) int {
+	if secs := int(ttl / time.Second); secs > 0 {
+		return secs
+	}
+	return 1
+}
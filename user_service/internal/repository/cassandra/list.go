@@ -0,0 +1,64 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"user_service/internal/repository"
+)
+
+// List scans the users table one Cassandra page at a time. The page token is
+// the driver's paging state, base64 encoded so that it can travel through the
+// REST gateway. Filters are evaluated by Cassandra with ALLOW FILTERING, which
+// is why a page can contain fewer than opts.PageSize users. Soft-deleted users
+// are skipped.
+func (r *UserRepository) List(ctx context.Context, opts repository.ListOptions) ([]*repository.User, string, error) {
+	var state []byte
+	if opts.PageToken != "" {
+		var err error
+		if state, err = base64.RawURLEncoding.DecodeString(opts.PageToken); err != nil {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+	}
+
+	var (
+		where []string
+		args  []interface{}
+	)
+	if opts.IsBlocked != nil {
+		where = append(where, "is_blocked = ?")
+		args = append(args, *opts.IsBlocked)
+	}
+	if opts.Gender != "" {
+		where = append(where, "gender = ?")
+		args = append(args, opts.Gender)
+	}
+	query := `SELECT ` + userColumns + `, deleted FROM users`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ") + ` ALLOW FILTERING`
+	}
+
+	iter := r.session.Query(query, args...).WithContext(ctx).PageSize(opts.PageSize).PageState(state).Iter()
+	var (
+		users   []*repository.User
+		u       repository.User
+		deleted bool
+	)
+	for iter.Scan(append(scanTargets(&u), &deleted)...) {
+		if deleted {
+			continue
+		}
+		row := u
+		users = append(users, &row)
+	}
+	next := iter.PageState()
+	if err := iter.Close(); err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if len(next) > 0 {
+		nextPageToken = base64.RawURLEncoding.EncodeToString(next)
+	}
+	return users, nextPageToken, nil
+}
@@ -0,0 +1,136 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gocql/gocql"
+	"hash/fnv"
+	"sort"
+	"time"
+	"user_service/internal/repository"
+)
+
+// OutboxBuckets is the number of partitions of the outbox_pending table.
+const OutboxBuckets = 16
+
+var _ repository.Outbox = (*UserRepository)(nil)
+
+// outboxEntry encodes e, with a snapshot of u, as an element of the outbox map
+// column of the users table. Keeping the outbox in the users row lets every
+// mutation record its event in the same conditional write, which Cassandra
+// cannot do across tables.
+func outboxEntry(e *repository.OutboxEvent, u *repository.User) (map[gocql.UUID]string, error) {
+	id := gocql.UUIDFromTime(u.UpdatedAt)
+	e.ID, e.User, e.OccurredAt = id.String(), *u, u.UpdatedAt
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return map[gocql.UUID]string{id: string(b)}, nil
+}
+
+// OutboxBucket returns the outbox_pending partition of a user.
+func OutboxBucket(userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(userID))
+	return int(h.Sum32() % OutboxBuckets)
+}
+
+// markPending records in outbox_pending that the user may have unpublished
+// events, so that the relay finds them without scanning the users table. It
+// is written before the event itself: a mark without events is dropped by
+// PendingEvents, while an event without a mark would never be published.
+func (r *UserRepository) markPending(ctx context.Context, userID string) error {
+	return r.session.Query(`INSERT INTO outbox_pending (bucket, user_id, marked_at) VALUES (?, ?, ?)`,
+		OutboxBucket(userID), userID, time.Now().UTC()).WithContext(ctx).Exec()
+}
+
+// PendingEvents reads the users marked in outbox_pending and returns their
+// outbox events, those of each user ordered by the version they were written
+// at. Marks of users whose outbox has been emptied are removed, unless a
+// write has marked the user again since they were read.
+func (r *UserRepository) PendingEvents(ctx context.Context, limit int) ([]*repository.OutboxEvent, error) {
+	var events []*repository.OutboxEvent
+	for bucket := 0; bucket < OutboxBuckets && len(events) < limit; bucket++ {
+		iter := r.session.Query(`SELECT user_id, WRITETIME(marked_at) FROM outbox_pending WHERE bucket = ?`, bucket).
+			WithContext(ctx).Iter()
+		var (
+			userID string
+			marked int64
+		)
+		for len(events) < limit && iter.Scan(&userID, &marked) {
+			outbox, err := r.outbox(ctx, userID)
+			if err != nil {
+				iter.Close()
+				return nil, err
+			}
+			if len(outbox) == 0 {
+				if err := r.session.Query(`DELETE FROM outbox_pending USING TIMESTAMP ? WHERE bucket = ? AND user_id = ?`,
+					marked, bucket, userID).WithContext(ctx).Exec(); err != nil {
+					iter.Close()
+					return nil, err
+				}
+				continue
+			}
+			if n := limit - len(events); len(outbox) > n {
+				outbox = outbox[:n]
+			}
+			events = append(events, outbox...)
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// outbox decodes the outbox column of a user, ordered by version. A purged
+// user has none.
+func (r *UserRepository) outbox(ctx context.Context, userID string) ([]*repository.OutboxEvent, error) {
+	var entries map[gocql.UUID]string
+	if err := r.session.Query(`SELECT outbox FROM users WHERE id = ?`, userID).WithContext(ctx).Scan(&entries); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	events := make([]*repository.OutboxEvent, 0, len(entries))
+	for id, entry := range entries {
+		var e repository.OutboxEvent
+		if err := json.Unmarshal([]byte(entry), &e); err != nil {
+			return nil, err
+		}
+		e.ID = id.String()
+		events = append(events, &e)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].User.Version < events[j].User.Version })
+	return events, nil
+}
+
+// RemoveEvents deletes the published elements from the outbox column of each
+// user. The removal is a lightweight transaction like every other write of
+// the users row, but leaves version and updated_at alone. The outbox_pending
+// marks are left to PendingEvents, which drops them once the outbox is empty.
+func (r *UserRepository) RemoveEvents(ctx context.Context, events []*repository.OutboxEvent) error {
+	byUser := make(map[string][]gocql.UUID)
+	var users []string
+	for _, e := range events {
+		id, err := gocql.ParseUUID(e.ID)
+		if err != nil {
+			return err
+		}
+		if _, ok := byUser[e.User.ID]; !ok {
+			users = append(users, e.User.ID)
+		}
+		byUser[e.User.ID] = append(byUser[e.User.ID], id)
+	}
+
+	for _, userID := range users {
+		query := `UPDATE users SET outbox = outbox - ? WHERE id = ? IF EXISTS`
+		if _, err := r.session.Query(query, byUser[userID], userID).WithContext(ctx).MapScanCAS(map[string]interface{}{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"github.com/gocql/gocql"
+	"log"
+	"user_service/internal/repository"
+)
+
+// contactIndex describes one of the lookup tables that doubles as a uniqueness
+// reservation for a contact field.
+type contactIndex struct {
+	table  string
+	column string
+	dupErr error
+}
+
+var (
+	emailIndex = contactIndex{table: "users_by_email", column: "email", dupErr: repository.ErrDuplicateEmail}
+	phoneIndex = contactIndex{table: "users_by_phone", column: "phone_number", dupErr: repository.ErrDuplicatePhoneNumber}
+)
+
+// reservation is a lookup entry claimed for a user by reserve.
+type reservation struct {
+	index contactIndex
+	key   string
+}
+
+// reserve claims key for userID with a lightweight transaction. It succeeds if
+// the key is free or already owned by userID, and returns the index's
+// duplicate error if another user owns it.
+func (r *UserRepository) reserve(ctx context.Context, idx contactIndex, key, userID string) error {
+	query := `INSERT INTO ` + idx.table + ` (` + idx.column + `, user_id) VALUES (?, ?) IF NOT EXISTS`
+	var existingKey, owner string
+	applied, err := r.session.Query(query, key, userID).WithContext(ctx).ScanCAS(&existingKey, &owner)
+	if err != nil {
+		return err
+	}
+	if !applied && owner != userID {
+		return idx.dupErr
+	}
+	return nil
+}
+
+// release removes a lookup entry, but only while it still belongs to userID.
+func (r *UserRepository) release(ctx context.Context, idx contactIndex, key, userID string) error {
+	query := `DELETE FROM ` + idx.table + ` WHERE ` + idx.column + ` = ? IF user_id = ?`
+	var owner string
+	_, err := r.session.Query(query, key, userID).WithContext(ctx).ScanCAS(&owner)
+	return err
+}
+
+// checkAvailable reports the index's duplicate error if any of the keys is
+// owned by a user other than userID. Unlike reserve it claims nothing, so the
+// answer may be stale by the time the keys are reserved.
+func (r *UserRepository) checkAvailable(ctx context.Context, userID string, rs ...reservation) error {
+	for _, res := range rs {
+		query := `SELECT user_id FROM ` + res.index.table + ` WHERE ` + res.index.column + ` = ?`
+		var owner string
+		if err := r.session.Query(query, res.key).WithContext(ctx).Scan(&owner); err != nil {
+			if errors.Is(err, gocql.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if owner != userID {
+			return res.index.dupErr
+		}
+	}
+	return nil
+}
+
+// reserveAll claims every reservation in order. If one of them fails, the ones
+// already claimed are released before the error is returned.
+func (r *UserRepository) reserveAll(ctx context.Context, userID string, rs ...reservation) error {
+	for i, res := range rs {
+		if err := r.reserve(ctx, res.index, res.key, userID); err != nil {
+			r.releaseAll(ctx, userID, rs[:i]...)
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseAll rolls back reservations, logging failures since the caller is
+// already returning an error of its own.
+func (r *UserRepository) releaseAll(ctx context.Context, userID string, rs ...reservation) {
+	for _, res := range rs {
+		if err := r.release(ctx, res.index, res.key, userID); err != nil {
+			log.Printf("Failed to release %s reservation for user %s: %v", res.index.column, userID, err)
+		}
+	}
+}
+
+// contactReservations returns the reservations needed for the non-empty
+// contact fields.
+func contactReservations(phoneNumber, email string) []reservation {
+	var rs []reservation
+	if email != "" {
+		rs = append(rs, reservation{index: emailIndex, key: email})
+	}
+	if phoneNumber != "" {
+		rs = append(rs, reservation{index: phoneIndex, key: phoneNumber})
+	}
+	return rs
+}
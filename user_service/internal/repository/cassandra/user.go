@@ -0,0 +1,415 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gocql/gocql"
+	"strings"
+	"time"
+	"user_service/internal/repository"
+)
+
+const userColumns = `id, first_name, last_name, gender, date_of_birth, phone_number, email, is_blocked, ` +
+	`email_verified, phone_verified, pending_email, pending_phone_number, block_reason, blocked_by, blocked_at, block_expires_at, created_at, updated_at, version`
+
+// maxUpdateAttempts bounds how often a versioned write is retried after losing
+// a race against a concurrent writer.
+const maxUpdateAttempts = 5
+
+// UserRepository is the Cassandra backed implementation of repository.UserRepository.
+type UserRepository struct {
+	session *gocql.Session
+}
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+func NewUserRepository(session *gocql.Session) *UserRepository {
+	return &UserRepository{session: session}
+}
+
+// assignment is a single "column = ?" clause of an UPDATE statement.
+type assignment struct {
+	column string
+	value  interface{}
+}
+
+// Create marks the user in outbox_pending, reserves the user's email and phone
+// number in the users_by_email and users_by_phone lookup tables and then
+// inserts the users row together with its UserCreated event. The reservations are released again if the insert
+// fails.
+func (r *UserRepository) Create(ctx context.Context, u *repository.User) error {
+	entry, err := outboxEntry(&repository.OutboxEvent{Type: repository.EventUserCreated}, u)
+	if err != nil {
+		return err
+	}
+	if err := r.markPending(ctx, u.ID); err != nil {
+		return err
+	}
+	rs := contactReservations(u.PhoneNumber, u.Email)
+	if err := r.reserveAll(ctx, u.ID, rs...); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO users (` + userColumns + `, deleted, outbox) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, false, ?)`
+	if err := r.session.Query(query, u.ID, u.FirstName, u.LastName, u.Gender, u.DateOfBirth, u.PhoneNumber, u.Email, u.IsBlocked,
+		u.EmailVerified, u.PhoneVerified, nullString(u.PendingEmail), nullString(u.PendingPhoneNumber),
+		nullString(u.BlockReason), nullString(u.BlockedBy), nullTime(u.BlockedAt), nullTime(u.BlockExpiresAt),
+		u.CreatedAt, u.UpdatedAt, u.Version, entry).WithContext(ctx).Exec(); err != nil {
+		r.releaseAll(ctx, u.ID, rs...)
+		return err
+	}
+	return nil
+}
+
+// Get fetches a user by id. Soft-deleted users are reported as not found.
+func (r *UserRepository) Get(ctx context.Context, id string) (*repository.User, error) {
+	u, deleted, err := r.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		return nil, repository.ErrNotFound
+	}
+	return u, nil
+}
+
+// GetByEmail resolves the email through the users_by_email lookup table and
+// fetches the owning user.
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*repository.User, error) {
+	return r.lookup(ctx, `SELECT user_id FROM users_by_email WHERE email = ?`, email)
+}
+
+// GetByPhoneNumber resolves the phone number through the users_by_phone lookup
+// table and fetches the owning user.
+func (r *UserRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*repository.User, error) {
+	return r.lookup(ctx, `SELECT user_id FROM users_by_phone WHERE phone_number = ?`, phoneNumber)
+}
+
+// Update writes only the profile columns set in upd. Unknown and soft-deleted
+// ids are reported as repository.ErrNotFound instead of creating a partial row.
+func (r *UserRepository) Update(ctx context.Context, id string, upd repository.ProfileUpdate, expectedVersion int64) (*repository.User, error) {
+	return r.update(ctx, id, expectedVersion, func(u *repository.User) ([]assignment, *repository.OutboxEvent, error) {
+		if u.IsBlocked {
+			return nil, nil, repository.ErrUserBlocked
+		}
+		var set []assignment
+		for _, col := range []struct {
+			name   string
+			value  *string
+			stored *string
+		}{
+			{"first_name", upd.FirstName, &u.FirstName},
+			{"last_name", upd.LastName, &u.LastName},
+			{"gender", upd.Gender, &u.Gender},
+			{"date_of_birth", upd.DateOfBirth, &u.DateOfBirth},
+		} {
+			if col.value != nil {
+				*col.stored = *col.value
+				set = append(set, assignment{col.name, *col.value})
+			}
+		}
+		return set, &repository.OutboxEvent{Type: repository.EventUserUpdated}, nil
+	})
+}
+
+// Block sets the is_blocked flag to true on an existing user and stores the
+// block details.
+func (r *UserRepository) Block(ctx context.Context, id string, block repository.BlockDetails, expectedVersion int64) (*repository.User, error) {
+	return r.update(ctx, id, expectedVersion, func(u *repository.User) ([]assignment, *repository.OutboxEvent, error) {
+		u.IsBlocked = true
+		u.BlockReason, u.BlockedBy, u.BlockedAt, u.BlockExpiresAt = block.Reason, block.BlockedBy, now(), block.ExpiresAt
+		return []assignment{
+			{"is_blocked", true},
+			{"block_reason", nullString(u.BlockReason)},
+			{"blocked_by", nullString(u.BlockedBy)},
+			{"blocked_at", u.BlockedAt},
+			{"block_expires_at", nullTime(u.BlockExpiresAt)},
+		}, &repository.OutboxEvent{Type: repository.EventUserBlocked}, nil
+	})
+}
+
+// Unblock sets the is_blocked flag to false on an existing user and clears the
+// block details.
+func (r *UserRepository) Unblock(ctx context.Context, id string, expectedVersion int64) (*repository.User, error) {
+	return r.update(ctx, id, expectedVersion, func(u *repository.User) ([]assignment, *repository.OutboxEvent, error) {
+		u.IsBlocked = false
+		u.BlockReason, u.BlockedBy, u.BlockedAt, u.BlockExpiresAt = "", "", time.Time{}, time.Time{}
+		return []assignment{
+			{"is_blocked", false},
+			{"block_reason", nil},
+			{"blocked_by", nil},
+			{"blocked_at", nil},
+			{"block_expires_at", nil},
+		}, &repository.OutboxEvent{Type: repository.EventUserUnblocked}, nil
+	})
+}
+
+// ListExpiredBlocks scans for blocked users whose block_expires_at has passed.
+// Like List it relies on ALLOW FILTERING, which is acceptable for a periodic
+// background sweep.
+func (r *UserRepository) ListExpiredBlocks(ctx context.Context, now time.Time) ([]*repository.User, error) {
+	query := `SELECT ` + userColumns + `, deleted FROM users WHERE is_blocked = true AND block_expires_at <= ? ALLOW FILTERING`
+	iter := r.session.Query(query, now).WithContext(ctx).Iter()
+	var (
+		users   []*repository.User
+		u       repository.User
+		deleted bool
+	)
+	for iter.Scan(append(scanTargets(&u), &deleted)...) {
+		if deleted {
+			continue
+		}
+		row := u
+		users = append(users, &row)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// StageContact writes the changed phone number and email to the pending
+// columns. The lookup tables are only checked here; the new values are
+// reserved by ConfirmContact.
+func (r *UserRepository) StageContact(ctx context.Context, id, phoneNumber, email string, expectedVersion int64) (*repository.User, error) {
+	if err := r.checkAvailable(ctx, id, contactReservations(phoneNumber, email)...); err != nil {
+		return nil, err
+	}
+	return r.update(ctx, id, expectedVersion, func(u *repository.User) ([]assignment, *repository.OutboxEvent, error) {
+		if u.IsBlocked {
+			return nil, nil, repository.ErrUserBlocked
+		}
+		u.PendingPhoneNumber, u.PendingEmail = "", ""
+		if u.PhoneNumber != phoneNumber {
+			u.PendingPhoneNumber = phoneNumber
+		}
+		if u.Email != email {
+			u.PendingEmail = email
+		}
+		return []assignment{
+			{"pending_phone_number", nullString(u.PendingPhoneNumber)},
+			{"pending_email", nullString(u.PendingEmail)},
+		}, &repository.OutboxEvent{Type: repository.EventUserUpdated}, nil
+	})
+}
+
+// ConfirmContact sets the verified column of the channel. A matching pending
+// contact is first reserved in the lookup table and moved into place, and the
+// lookup entry of the previous contact is released once the row is written.
+func (r *UserRepository) ConfirmContact(ctx context.Context, id string, channel repository.ContactChannel, value string) (*repository.User, error) {
+	cols := channelColumns[channel]
+	var added, removed []reservation
+	u, err := r.update(ctx, id, 0, func(u *repository.User) ([]assignment, *repository.OutboxEvent, error) {
+		// A previous attempt lost a race; start over from the fresh row.
+		r.releaseAll(ctx, id, added...)
+		added, removed = nil, nil
+
+		current, pending, verified := contactFields(u, channel)
+		var set []assignment
+		e := &repository.OutboxEvent{Type: repository.EventUserUpdated}
+		switch {
+		case *pending != "" && *pending == value:
+			rs := []reservation{{index: cols.index, key: value}}
+			if err := r.reserveAll(ctx, id, rs...); err != nil {
+				return nil, nil, err
+			}
+			added = rs
+			if *current != "" {
+				removed = []reservation{{index: cols.index, key: *current}}
+			}
+			e = &repository.OutboxEvent{Type: repository.EventContactChanged, Channel: channel, PreviousContact: *current}
+			*current, *pending = value, ""
+			set = append(set, assignment{cols.value, value}, assignment{cols.pending, nil})
+		case *current != value:
+			return nil, nil, repository.ErrContactChanged
+		}
+		*verified = true
+		return append(set, assignment{cols.verified, true}), e, nil
+	})
+	if err != nil {
+		r.releaseAll(ctx, id, added...)
+		return nil, err
+	}
+	r.releaseAll(ctx, id, removed...)
+	return u, nil
+}
+
+// Delete soft-deletes a user by setting the deleted flag and deleted_at. The
+// row and its lookup entries are kept so the contact details stay reserved.
+func (r *UserRepository) Delete(ctx context.Context, id string, expectedVersion int64) error {
+	_, err := r.update(ctx, id, expectedVersion, func(u *repository.User) ([]assignment, *repository.OutboxEvent, error) {
+		return []assignment{{"deleted", true}, {"deleted_at", now()}}, nil, nil
+	})
+	return err
+}
+
+// Purge removes the users row, soft-deleted or not, together with its
+// unpublished events, and releases its lookup entries.
+func (r *UserRepository) Purge(ctx context.Context, id string) error {
+	u, _, err := r.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Every other write to the row is a lightweight transaction, and mixing
+	// them with plain writes on one row can apply them out of order.
+	applied, err := r.session.Query(`DELETE FROM users WHERE id = ? IF EXISTS`, id).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return repository.ErrNotFound
+	}
+	repository.RecordPrevious(ctx, u)
+	r.releaseAll(ctx, id, contactReservations(u.PhoneNumber, u.Email)...)
+	return nil
+}
+
+// update performs a versioned read-modify-write of a users row. apply receives
+// the current user, changes it in place and returns the columns to write and
+// the event to record, if any; updated_at and version are bumped
+// automatically, and the event is added to the outbox column in the same
+// write, after the user has been marked in outbox_pending. The write is
+// conditioned on the version that was read, and is retried with a fresh read if another
+// writer got there first. Unknown and soft-deleted ids are reported as
+// repository.ErrNotFound; a non-zero expectedVersion that does not match the
+// stored version is reported as repository.ErrVersionMismatch.
+func (r *UserRepository) update(ctx context.Context, id string, expectedVersion int64, apply func(u *repository.User) ([]assignment, *repository.OutboxEvent, error)) (*repository.User, error) {
+	marked := false
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		u, deleted, err := r.get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if deleted {
+			return nil, repository.ErrNotFound
+		}
+
+		readVersion := u.Version
+		if expectedVersion != 0 && readVersion != expectedVersion {
+			return nil, repository.ErrVersionMismatch
+		}
+		previous := *u
+		set, e, err := apply(u)
+		if err != nil {
+			return nil, err
+		}
+		u.UpdatedAt = now()
+		u.Version = readVersion + 1
+		set = append(set, assignment{"updated_at", u.UpdatedAt}, assignment{"version", u.Version})
+
+		clauses := make([]string, 0, len(set)+1)
+		args := make([]interface{}, 0, len(set)+3)
+		for _, a := range set {
+			clauses = append(clauses, a.column+" = ?")
+			args = append(args, a.value)
+		}
+		if e != nil {
+			entry, err := outboxEntry(e, u)
+			if err != nil {
+				return nil, err
+			}
+			if !marked {
+				if err := r.markPending(ctx, id); err != nil {
+					return nil, err
+				}
+				marked = true
+			}
+			clauses = append(clauses, "outbox = outbox + ?")
+			args = append(args, entry)
+		}
+		args = append(args, id, readVersion)
+
+		query := `UPDATE users SET ` + strings.Join(clauses, ", ") + ` WHERE id = ? IF deleted = false AND version = ?`
+		applied, err := r.session.Query(query, args...).WithContext(ctx).MapScanCAS(map[string]interface{}{})
+		if err != nil {
+			return nil, err
+		}
+		if applied {
+			repository.RecordPrevious(ctx, &previous)
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("user %s: gave up after %d concurrent modifications", id, maxUpdateAttempts)
+}
+
+// lookup reads a user id from one of the lookup tables and fetches the user.
+func (r *UserRepository) lookup(ctx context.Context, query string, key string) (*repository.User, error) {
+	var id string
+	if err := r.session.Query(query, key).WithContext(ctx).Scan(&id); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return r.Get(ctx, id)
+}
+
+// get fetches a user by id along with its soft-delete flag.
+func (r *UserRepository) get(ctx context.Context, id string) (*repository.User, bool, error) {
+	var (
+		u       repository.User
+		deleted bool
+	)
+	if err := r.session.Query(`SELECT `+userColumns+`, deleted FROM users WHERE id = ?`, id).WithContext(ctx).
+		Scan(append(scanTargets(&u), &deleted)...); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, false, repository.ErrNotFound
+		}
+		return nil, false, err
+	}
+	return &u, deleted, nil
+}
+
+// contactColumns names the columns and lookup index of a contact channel.
+type contactColumns struct {
+	value    string
+	pending  string
+	verified string
+	index    contactIndex
+}
+
+var channelColumns = map[repository.ContactChannel]contactColumns{
+	repository.ContactChannelEmail: {value: "email", pending: "pending_email", verified: "email_verified", index: emailIndex},
+	repository.ContactChannelPhone: {value: "phone_number", pending: "pending_phone_number", verified: "phone_verified", index: phoneIndex},
+}
+
+// contactFields returns pointers to the current, pending and verified fields
+// of the channel's contact.
+func contactFields(u *repository.User, channel repository.ContactChannel) (*string, *string, *bool) {
+	if channel == repository.ContactChannelPhone {
+		return &u.PhoneNumber, &u.PendingPhoneNumber, &u.PhoneVerified
+	}
+	return &u.Email, &u.PendingEmail, &u.EmailVerified
+}
+
+// scanTargets returns pointers to the fields of u in userColumns order.
+func scanTargets(u *repository.User) []interface{} {
+	return []interface{}{
+		&u.ID, &u.FirstName, &u.LastName, &u.Gender, &u.DateOfBirth, &u.PhoneNumber, &u.Email, &u.IsBlocked,
+		&u.EmailVerified, &u.PhoneVerified, &u.PendingEmail, &u.PendingPhoneNumber,
+		&u.BlockReason, &u.BlockedBy, &u.BlockedAt, &u.BlockExpiresAt,
+		&u.CreatedAt, &u.UpdatedAt, &u.Version,
+	}
+}
+
+// nullString binds an empty string as null so that unset columns stay unset.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullTime binds a zero time as null so that unset columns stay unset.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// now returns the current time at the millisecond precision Cassandra stores.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
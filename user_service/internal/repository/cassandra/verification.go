@@ -0,0 +1,80 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gocql/gocql"
+	"time"
+	"user_service/internal/repository"
+)
+
+// VerificationStore keeps pending contact verifications in the
+// contact_verifications table. Rows are written with a TTL so that expired
+// codes disappear on their own.
+type VerificationStore struct {
+	session *gocql.Session
+}
+
+var _ repository.VerificationStore = (*VerificationStore)(nil)
+
+func NewVerificationStore(session *gocql.Session) *VerificationStore {
+	return &VerificationStore{session: session}
+}
+
+// Put inserts the verification, overwriting the previous row of the same user
+// and channel.
+func (s *VerificationStore) Put(ctx context.Context, v *repository.Verification) error {
+	ttl := int(time.Until(v.ExpiresAt).Seconds()) + 1
+	query := `INSERT INTO contact_verifications (user_id, channel, destination, code_hash, attempts, created_at, expires_at) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?) USING TTL ?`
+	return s.session.Query(query, v.UserID, string(v.Channel), v.Destination, v.CodeHash, v.Attempts,
+		v.CreatedAt, v.ExpiresAt, ttl).WithContext(ctx).Exec()
+}
+
+// Get reads the verification row. Rows past their expiry that have not been
+// removed by the TTL yet are reported as not found.
+func (s *VerificationStore) Get(ctx context.Context, userID string, channel repository.ContactChannel) (*repository.Verification, error) {
+	v := repository.Verification{UserID: userID, Channel: channel}
+	query := `SELECT destination, code_hash, attempts, created_at, expires_at FROM contact_verifications WHERE user_id = ? AND channel = ?`
+	if err := s.session.Query(query, userID, string(channel)).WithContext(ctx).
+		Scan(&v.Destination, &v.CodeHash, &v.Attempts, &v.CreatedAt, &v.ExpiresAt); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, repository.ErrVerificationNotFound
+		}
+		return nil, err
+	}
+	if !v.ExpiresAt.After(time.Now()) {
+		return nil, repository.ErrVerificationNotFound
+	}
+	return &v, nil
+}
+
+// IncrementAttempts bumps the attempts column with a lightweight transaction
+// conditioned on the value read, retrying if a concurrent attempt got there
+// first. The update keeps the row's remaining TTL.
+func (s *VerificationStore) IncrementAttempts(ctx context.Context, userID string, channel repository.ContactChannel) (int, error) {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		v, err := s.Get(ctx, userID, channel)
+		if err != nil {
+			return 0, err
+		}
+		ttl := int(time.Until(v.ExpiresAt).Seconds()) + 1
+		query := `UPDATE contact_verifications USING TTL ? SET attempts = ? WHERE user_id = ? AND channel = ? IF attempts = ?`
+		applied, err := s.session.Query(query, ttl, v.Attempts+1, userID, string(channel), v.Attempts).
+			WithContext(ctx).MapScanCAS(map[string]interface{}{})
+		if err != nil {
+			return 0, err
+		}
+		if applied {
+			return v.Attempts + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("verification of user %s: gave up after %d concurrent attempts", userID, maxUpdateAttempts)
+}
+
+// Delete removes the verification row.
+func (s *VerificationStore) Delete(ctx context.Context, userID string, channel repository.ContactChannel) error {
+	return s.session.Query(`DELETE FROM contact_verifications WHERE user_id = ? AND channel = ?`, userID, string(channel)).
+		WithContext(ctx).Exec()
+}
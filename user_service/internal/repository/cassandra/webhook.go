@@ -0,0 +1,337 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/gocql/gocql"
+	"sort"
+	"time"
+	"user_service/internal/repository"
+)
+
+const (
+	// defaultDeliveryRetention is used by NewWebhookStore when given no
+	// retention.
+	defaultDeliveryRetention = 7 * 24 * time.Hour
+
+	// dueBucketSize is the span of attempt times sharing a webhook_due
+	// partition.
+	dueBucketSize = time.Hour
+
+	// dueShard is the only partition of webhook_due_buckets, which lists the
+	// webhook_due partitions that may hold entries and so stays small.
+	dueShard = 0
+)
+
+// WebhookStore keeps webhooks in the webhooks table and their deliveries in
+// webhook_deliveries, one partition per webhook clustered by the event's time
+// UUID in descending order. The attempts of a delivery are stored as a JSON
+// document. Pending deliveries are queued in webhook_due by the time of their
+// next attempt, and finished ones expire after the retention.
+type WebhookStore struct {
+	session   *gocql.Session
+	retention time.Duration
+}
+
+var _ repository.WebhookStore = (*WebhookStore)(nil)
+
+// NewWebhookStore returns a store keeping succeeded and dead-lettered
+// deliveries for retention.
+func NewWebhookStore(session *gocql.Session, retention time.Duration) *WebhookStore {
+	if retention <= 0 {
+		retention = defaultDeliveryRetention
+	}
+	return &WebhookStore{session: session, retention: retention}
+}
+
+// DueBucket returns the webhook_due partition of deliveries due at t.
+func DueBucket(t time.Time) time.Time {
+	return t.UTC().Truncate(dueBucketSize)
+}
+
+const deliveryColumns = `webhook_id, event_id, user_id, event_type, payload, status, created_at, next_attempt_at, attempts`
+
+func (s *WebhookStore) CreateWebhook(ctx context.Context, w *repository.Webhook) error {
+	query := `INSERT INTO webhooks (id, url, event_types, secret, created_at) VALUES (?, ?, ?, ?, ?)`
+	return s.session.Query(query, w.ID, w.URL, eventTypeStrings(w.EventTypes), w.Secret, w.CreatedAt).WithContext(ctx).Exec()
+}
+
+func (s *WebhookStore) GetWebhook(ctx context.Context, id string) (*repository.Webhook, error) {
+	query := `SELECT id, url, event_types, secret, created_at FROM webhooks WHERE id = ?`
+	var (
+		w     repository.Webhook
+		types []string
+	)
+	if err := s.session.Query(query, id).WithContext(ctx).Scan(&w.ID, &w.URL, &types, &w.Secret, &w.CreatedAt); err != nil {
+		if errors.Is(err, gocql.ErrNotFound) {
+			return nil, repository.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	w.EventTypes = eventTypes(types)
+	return &w, nil
+}
+
+// ListWebhooks scans the webhooks table, which is expected to stay small.
+func (s *WebhookStore) ListWebhooks(ctx context.Context) ([]*repository.Webhook, error) {
+	iter := s.session.Query(`SELECT id, url, event_types, secret, created_at FROM webhooks`).WithContext(ctx).Iter()
+	var (
+		webhooks []*repository.Webhook
+		w        repository.Webhook
+		types    []string
+	)
+	for iter.Scan(&w.ID, &w.URL, &types, &w.Secret, &w.CreatedAt) {
+		row := w
+		row.EventTypes = eventTypes(types)
+		webhooks = append(webhooks, &row)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+	return webhooks, nil
+}
+
+func (s *WebhookStore) DeleteWebhook(ctx context.Context, id string) error {
+	applied, err := s.session.Query(`DELETE FROM webhooks WHERE id = ? IF EXISTS`, id).WithContext(ctx).
+		MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return repository.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// EnqueueDelivery queues the delivery in webhook_due and then inserts it with
+// a lightweight transaction, so that an event republished by the outbox relay
+// does not reset its attempts. The queue entry of a delivery that already
+// existed is dropped by DueDeliveries.
+func (s *WebhookStore) EnqueueDelivery(ctx context.Context, d *repository.Delivery) error {
+	attempts, err := json.Marshal(d.Attempts)
+	if err != nil {
+		return err
+	}
+	if err := s.schedule(ctx, d.WebhookID, d.EventID, d.NextAttemptAt); err != nil {
+		return err
+	}
+	query := `INSERT INTO webhook_deliveries (` + deliveryColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) IF NOT EXISTS`
+	_, err = s.session.Query(query, d.WebhookID, d.EventID, d.UserID, string(d.EventType), string(d.Payload),
+		string(d.Status), d.CreatedAt, nullTime(d.NextAttemptAt), string(attempts)).
+		WithContext(ctx).MapScanCAS(map[string]interface{}{})
+	return err
+}
+
+// DueDeliveries reads the webhook_due partitions up to now, oldest first. An
+// entry is only a hint: it is dropped if its delivery is no longer pending
+// at that time. Partitions before the current one are forgotten once they
+// are empty, as no delivery is ever queued in the past.
+func (s *WebhookStore) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*repository.Delivery, error) {
+	current := DueBucket(now)
+	var buckets []time.Time
+	iter := s.session.Query(`SELECT bucket FROM webhook_due_buckets WHERE shard = ? AND bucket <= ?`, dueShard, current).
+		WithContext(ctx).Iter()
+	var bucket time.Time
+	for iter.Scan(&bucket) {
+		buckets = append(buckets, bucket)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	var deliveries []*repository.Delivery
+	for _, bucket := range buckets {
+		if len(deliveries) == limit {
+			break
+		}
+		due, empty, err := s.dueIn(ctx, bucket, now, limit-len(deliveries))
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, due...)
+		if empty && bucket.Before(current) {
+			if err := s.session.Query(`DELETE FROM webhook_due_buckets WHERE shard = ? AND bucket = ?`, dueShard, bucket).
+				WithContext(ctx).Exec(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return deliveries, nil
+}
+
+// dueIn returns up to limit pending deliveries queued in the bucket that are
+// due at now, and whether the bucket holds no entries.
+func (s *WebhookStore) dueIn(ctx context.Context, bucket, now time.Time, limit int) ([]*repository.Delivery, bool, error) {
+	iter := s.session.Query(`SELECT due_at, webhook_id, event_id FROM webhook_due WHERE bucket = ? AND due_at <= ?`, bucket, now).
+		WithContext(ctx).Iter()
+	var (
+		deliveries         []*repository.Delivery
+		dueAt              time.Time
+		webhookID, eventID string
+		empty              = true
+	)
+	for len(deliveries) < limit && iter.Scan(&dueAt, &webhookID, &eventID) {
+		empty = false
+		d, err := s.getDelivery(ctx, webhookID, eventID)
+		if err != nil && !errors.Is(err, gocql.ErrNotFound) {
+			iter.Close()
+			return nil, false, err
+		}
+		if err == nil && d.Status == repository.DeliveryPending && d.NextAttemptAt.Equal(dueAt) {
+			deliveries = append(deliveries, d)
+			continue
+		}
+		if err := s.unschedule(ctx, webhookID, eventID, dueAt); err != nil {
+			iter.Close()
+			return nil, false, err
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, false, err
+	}
+	return deliveries, empty, nil
+}
+
+// UpdateDelivery stores the outcome of an attempt. A delivery still pending is
+// queued for its next attempt before the row is written; a finished one is
+// rewritten in full with the retention as TTL, so that the whole row expires.
+// The queue entry of the attempt that was made is removed afterwards.
+func (s *WebhookStore) UpdateDelivery(ctx context.Context, d *repository.Delivery) error {
+	attempts, err := json.Marshal(d.Attempts)
+	if err != nil {
+		return err
+	}
+	var previous *time.Time
+	if err := s.session.Query(`SELECT next_attempt_at FROM webhook_deliveries WHERE webhook_id = ? AND event_id = ?`,
+		d.WebhookID, d.EventID).WithContext(ctx).Scan(&previous); err != nil && !errors.Is(err, gocql.ErrNotFound) {
+		return err
+	}
+
+	if d.Status == repository.DeliveryPending {
+		if err := s.schedule(ctx, d.WebhookID, d.EventID, d.NextAttemptAt); err != nil {
+			return err
+		}
+		query := `UPDATE webhook_deliveries SET status = ?, next_attempt_at = ?, attempts = ? WHERE webhook_id = ? AND event_id = ?`
+		err = s.session.Query(query, string(d.Status), nullTime(d.NextAttemptAt), string(attempts), d.WebhookID, d.EventID).
+			WithContext(ctx).Exec()
+	} else {
+		query := `INSERT INTO webhook_deliveries (` + deliveryColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) USING TTL ?`
+		err = s.session.Query(query, d.WebhookID, d.EventID, d.UserID, string(d.EventType), string(d.Payload),
+			string(d.Status), d.CreatedAt, nullTime(d.NextAttemptAt), string(attempts), int(s.retention.Seconds())).
+			WithContext(ctx).Exec()
+	}
+	if err != nil {
+		return err
+	}
+
+	if previous != nil && !previous.IsZero() && !previous.Equal(d.NextAttemptAt) {
+		return s.unschedule(ctx, d.WebhookID, d.EventID, *previous)
+	}
+	return nil
+}
+
+// schedule queues the delivery for an attempt at dueAt.
+func (s *WebhookStore) schedule(ctx context.Context, webhookID, eventID string, dueAt time.Time) error {
+	bucket := DueBucket(dueAt)
+	if err := s.session.Query(`INSERT INTO webhook_due_buckets (shard, bucket) VALUES (?, ?)`, dueShard, bucket).
+		WithContext(ctx).Exec(); err != nil {
+		return err
+	}
+	return s.session.Query(`INSERT INTO webhook_due (bucket, due_at, webhook_id, event_id) VALUES (?, ?, ?, ?)`,
+		bucket, dueAt, webhookID, eventID).WithContext(ctx).Exec()
+}
+
+// unschedule removes the queue entry of the delivery's attempt at dueAt.
+func (s *WebhookStore) unschedule(ctx context.Context, webhookID, eventID string, dueAt time.Time) error {
+	return s.session.Query(`DELETE FROM webhook_due WHERE bucket = ? AND due_at = ? AND webhook_id = ? AND event_id = ?`,
+		DueBucket(dueAt), dueAt, webhookID, eventID).WithContext(ctx).Exec()
+}
+
+// getDelivery reads one delivery row, returning gocql.ErrNotFound if it does
+// not exist or has expired.
+func (s *WebhookStore) getDelivery(ctx context.Context, webhookID, eventID string) (*repository.Delivery, error) {
+	iter := s.session.Query(`SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE webhook_id = ? AND event_id = ?`,
+		webhookID, eventID).WithContext(ctx).Iter()
+	deliveries, err := scanDeliveries(iter)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, gocql.ErrNotFound
+	}
+	return deliveries[0], nil
+}
+
+// ListDeliveries reads one page of the webhook's partition. As with
+// UserRepository.List the page token is the driver's paging state, base64
+// encoded.
+func (s *WebhookStore) ListDeliveries(ctx context.Context, q repository.DeliveryQuery) ([]*repository.Delivery, string, error) {
+	var state []byte
+	if q.PageToken != "" {
+		var err error
+		if state, err = base64.RawURLEncoding.DecodeString(q.PageToken); err != nil {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE webhook_id = ?`
+	args := []interface{}{q.WebhookID}
+	if q.Status != "" {
+		query += ` AND status = ? ALLOW FILTERING`
+		args = append(args, string(q.Status))
+	}
+
+	iter := s.session.Query(query, args...).WithContext(ctx).PageSize(q.PageSize).PageState(state).Iter()
+	deliveries, err := scanDeliveries(iter)
+	if err != nil {
+		return nil, "", err
+	}
+	var nextPageToken string
+	if next := iter.PageState(); len(next) > 0 {
+		nextPageToken = base64.RawURLEncoding.EncodeToString(next)
+	}
+	return deliveries, nextPageToken, nil
+}
+
+// scanDeliveries reads the deliveryColumns rows of iter and closes it.
+func scanDeliveries(iter *gocql.Iter) ([]*repository.Delivery, error) {
+	var (
+		deliveries                           []*repository.Delivery
+		d                                    repository.Delivery
+		eventType, payload, status, attempts string
+	)
+	for iter.Scan(&d.WebhookID, &d.EventID, &d.UserID, &eventType, &payload, &status, &d.CreatedAt, &d.NextAttemptAt, &attempts) {
+		row := d
+		row.EventType, row.Payload, row.Status, row.Attempts = repository.EventType(eventType), []byte(payload), repository.DeliveryStatus(status), nil
+		if err := json.Unmarshal([]byte(attempts), &row.Attempts); err != nil {
+			iter.Close()
+			return nil, err
+		}
+		deliveries = append(deliveries, &row)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func eventTypeStrings(types []repository.EventType) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
+
+func eventTypes(types []string) []repository.EventType {
+	out := make([]repository.EventType, len(types))
+	for i, t := range types {
+		out[i] = repository.EventType(t)
+	}
+	return out
+}
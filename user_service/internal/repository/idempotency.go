@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord is the stored outcome of a request made with an
+// idempotency key.
+type IdempotencyRecord struct {
+	// Fingerprint identifies the request body the key was first used with.
+	Fingerprint string
+	// Response is the serialized response, or nil while the first request is
+	// still in progress.
+	Response []byte
+}
+
+// IdempotencyStore remembers the responses of requests carrying an
+// idempotency key so that retries can be answered without repeating them.
+type IdempotencyStore interface {
+	// Begin claims key for a request with the given fingerprint for lease. It
+	// returns nil if the key was free, or the existing record if the key has
+	// already been used. A claim that is neither completed nor abandoned, say
+	// because the server crashed, frees the key once the lease runs out.
+	Begin(ctx context.Context, key, fingerprint string, lease time.Duration) (*IdempotencyRecord, error)
+
+	// Complete stores the response for a key claimed by Begin and keeps the
+	// record for ttl.
+	Complete(ctx context.Context, key, fingerprint string, response []byte, ttl time.Duration) error
+
+	// Abandon releases a key claimed by Begin after the request failed, so
+	// that it can be retried.
+	Abandon(ctx context.Context, key string) error
+}
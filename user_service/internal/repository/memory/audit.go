@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"sync"
+	"user_service/internal/repository"
+)
+
+// AuditStore is an in-process implementation of repository.AuditStore.
+type AuditStore struct {
+	mu     sync.RWMutex
+	events map[string][]repository.AuditEvent
+}
+
+var _ repository.AuditStore = (*AuditStore)(nil)
+
+func NewAuditStore() *AuditStore {
+	return &AuditStore{events: make(map[string][]repository.AuditEvent)}
+}
+
+// Append adds the event to the user's audit log.
+func (s *AuditStore) Append(ctx context.Context, e *repository.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[e.UserID] = append(s.events[e.UserID], *e)
+	return nil
+}
+
+// List returns the user's events in the time range newest first. The page
+// token is the base64 encoded number of matching events already returned.
+func (s *AuditStore) List(ctx context.Context, q repository.AuditQuery) ([]*repository.AuditEvent, string, error) {
+	offset := 0
+	if q.PageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(q.PageToken)
+		if err != nil {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+		if offset, err = strconv.Atoi(string(b)); err != nil || offset < 0 {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	log := s.events[q.UserID]
+	var (
+		events  []*repository.AuditEvent
+		matched int
+	)
+	for i := len(log) - 1; i >= 0; i-- {
+		e := log[i]
+		if !q.From.IsZero() && e.OccurredAt.Before(q.From) || !q.To.IsZero() && e.OccurredAt.After(q.To) {
+			continue
+		}
+		matched++
+		if matched <= offset {
+			continue
+		}
+		if len(events) == q.PageSize {
+			return events, base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset + len(events)))), nil
+		}
+		events = append(events, &e)
+	}
+	return events, "", nil
+}
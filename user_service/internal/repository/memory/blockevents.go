@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"sync"
+	"user_service/internal/repository"
+)
+
+// BlockEventStore is an in-process implementation of repository.BlockEventStore.
+type BlockEventStore struct {
+	mu     sync.RWMutex
+	events map[string][]repository.BlockEvent
+}
+
+var _ repository.BlockEventStore = (*BlockEventStore)(nil)
+
+func NewBlockEventStore() *BlockEventStore {
+	return &BlockEventStore{events: make(map[string][]repository.BlockEvent)}
+}
+
+// Append adds the event to the user's history.
+func (s *BlockEventStore) Append(ctx context.Context, e *repository.BlockEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[e.UserID] = append(s.events[e.UserID], *e)
+	return nil
+}
+
+// List returns the user's events newest first. The page token is the base64
+// encoded number of events already returned.
+func (s *BlockEventStore) List(ctx context.Context, userID string, pageSize int, pageToken string) ([]*repository.BlockEvent, string, error) {
+	offset := 0
+	if pageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+		if offset, err = strconv.Atoi(string(b)); err != nil || offset < 0 {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := s.events[userID]
+	var events []*repository.BlockEvent
+	for i := len(history) - 1 - offset; i >= 0 && len(events) < pageSize; i-- {
+		e := history[i]
+		events = append(events, &e)
+	}
+	if next := offset + len(events); next < len(history) {
+		return events, base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(next))), nil
+	}
+	return events, "", nil
+}
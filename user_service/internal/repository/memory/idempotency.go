@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+	"user_service/internal/repository"
+)
+
+// idempotencySweepInterval is how often Begin and Complete drop every expired
+// record, so that keys that are never reused do not pile up.
+const idempotencySweepInterval = time.Minute
+
+// IdempotencyStore is an in-process implementation of repository.IdempotencyStore.
+type IdempotencyStore struct {
+	mu        sync.Mutex
+	records   map[string]*idempotencyEntry
+	nextSweep time.Time
+}
+
+type idempotencyEntry struct {
+	record    repository.IdempotencyRecord
+	expiresAt time.Time
+}
+
+var _ repository.IdempotencyStore = (*IdempotencyStore)(nil)
+
+func NewIdempotencyStore() *IdempotencyStore {
+	return &IdempotencyStore{records: make(map[string]*idempotencyEntry)}
+}
+
+// Begin claims the key unless an unexpired record exists for it. An expired
+// record is dropped.
+func (s *IdempotencyStore) Begin(ctx context.Context, key, fingerprint string, lease time.Duration) (*repository.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	if e, ok := s.records[key]; ok {
+		if now.Before(e.expiresAt) {
+			rec := e.record
+			return &rec, nil
+		}
+		delete(s.records, key)
+	}
+	s.records[key] = &idempotencyEntry{
+		record:    repository.IdempotencyRecord{Fingerprint: fingerprint},
+		expiresAt: now.Add(lease),
+	}
+	return nil, nil
+}
+
+// Complete stores the response for the key, replacing the claim.
+func (s *IdempotencyStore) Complete(ctx context.Context, key, fingerprint string, response []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	s.records[key] = &idempotencyEntry{
+		record:    repository.IdempotencyRecord{Fingerprint: fingerprint, Response: response},
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
+
+// Abandon forgets the key.
+func (s *IdempotencyStore) Abandon(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// sweep drops the expired records, at most once per
+// idempotencySweepInterval. The caller holds s.mu.
+func (s *IdempotencyStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for key, e := range s.records {
+		if !now.Before(e.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+	s.nextSweep = now.Add(idempotencySweepInterval)
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyExpiry(t *testing.T) {
+	ctx := context.Background()
+	s := NewIdempotencyStore()
+
+	if rec, err := s.Begin(ctx, "a", "fp-a", time.Millisecond); rec != nil || err != nil {
+		t.Fatalf("Begin(a) = %v, %v; want a free key", rec, err)
+	}
+	if err := s.Complete(ctx, "b", "fp-b", []byte("response"), time.Hour); err != nil {
+		t.Fatalf("Complete(b): %v", err)
+	}
+	if rec, err := s.Begin(ctx, "b", "fp-b", time.Minute); err != nil || rec == nil || string(rec.Response) != "response" {
+		t.Fatalf("Begin(b) = %v, %v; want the stored response", rec, err)
+	}
+	time.Sleep(2 * time.Millisecond)
+
+	// An expired claim frees the key.
+	if rec, err := s.Begin(ctx, "a", "fp-other", time.Minute); rec != nil || err != nil {
+		t.Fatalf("Begin(a) after expiry = %v, %v; want a free key", rec, err)
+	}
+
+	// Keys that are never used again are dropped by the periodic sweep.
+	if _, err := s.Begin(ctx, "c", "fp-c", time.Millisecond); err != nil {
+		t.Fatalf("Begin(c): %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	s.nextSweep = time.Time{}
+	if _, err := s.Begin(ctx, "d", "fp-d", time.Minute); err != nil {
+		t.Fatalf("Begin(d): %v", err)
+	}
+	for key, want := range map[string]bool{"a": true, "b": true, "c": false, "d": true} {
+		if _, ok := s.records[key]; ok != want {
+			t.Errorf("record %q kept = %v, want %v", key, ok, want)
+		}
+	}
+}
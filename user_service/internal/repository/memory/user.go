@@ -0,0 +1,392 @@
+package memory
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+	"time"
+	"user_service/internal/repository"
+)
+
+// UserRepository is an in-process implementation of repository.UserRepository
+// and repository.Outbox. It is meant for tests and local development where no
+// Cassandra cluster is available; all data is lost when the process exits.
+type UserRepository struct {
+	mu      sync.RWMutex
+	users   map[string]*repository.User
+	byEmail map[string]string
+	byPhone map[string]string
+	deleted map[string]time.Time
+	outbox  []repository.OutboxEvent
+}
+
+var (
+	_ repository.UserRepository = (*UserRepository)(nil)
+	_ repository.Outbox         = (*UserRepository)(nil)
+)
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users:   make(map[string]*repository.User),
+		byEmail: make(map[string]string),
+		byPhone: make(map[string]string),
+		deleted: make(map[string]time.Time),
+	}
+}
+
+// Create stores a new user, rejecting duplicate email addresses and phone numbers.
+func (r *UserRepository) Create(ctx context.Context, u *repository.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkContact(u.ID, u.PhoneNumber, u.Email); err != nil {
+		return err
+	}
+
+	stored := *u
+	r.users[u.ID] = &stored
+	r.indexContact(&stored)
+	r.record(&repository.OutboxEvent{Type: repository.EventUserCreated}, &stored)
+	return nil
+}
+
+// Get returns the user with the given id.
+func (r *UserRepository) Get(ctx context.Context, id string) (*repository.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.get(id)
+}
+
+// GetByEmail returns the user owning the given email address.
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*repository.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byEmail[email]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return r.get(id)
+}
+
+// GetByPhoneNumber returns the user owning the given phone number.
+func (r *UserRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*repository.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byPhone[phoneNumber]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return r.get(id)
+}
+
+// List returns users ordered by id. The page token is the base64 encoded id of
+// the last user of the previous page.
+func (r *UserRepository) List(ctx context.Context, opts repository.ListOptions) ([]*repository.User, string, error) {
+	var after string
+	if opts.PageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(opts.PageToken)
+		if err != nil {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+		after = string(b)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.users))
+	for id := range r.users {
+		if _, deleted := r.deleted[id]; !deleted && id > after {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var users []*repository.User
+	for i, id := range ids {
+		stored := r.users[id]
+		if opts.IsBlocked != nil && stored.IsBlocked != *opts.IsBlocked {
+			continue
+		}
+		if opts.Gender != "" && stored.Gender != opts.Gender {
+			continue
+		}
+		u := *stored
+		users = append(users, &u)
+		if len(users) == opts.PageSize {
+			if i < len(ids)-1 {
+				return users, base64.RawURLEncoding.EncodeToString([]byte(id)), nil
+			}
+			break
+		}
+	}
+	return users, "", nil
+}
+
+// Update changes the non-nil profile fields of an existing user.
+func (r *UserRepository) Update(ctx context.Context, id string, upd repository.ProfileUpdate, expectedVersion int64) (*repository.User, error) {
+	return r.mutate(ctx, id, expectedVersion, func(stored *repository.User) (*repository.OutboxEvent, error) {
+		if stored.IsBlocked {
+			return nil, repository.ErrUserBlocked
+		}
+		if upd.FirstName != nil {
+			stored.FirstName = *upd.FirstName
+		}
+		if upd.LastName != nil {
+			stored.LastName = *upd.LastName
+		}
+		if upd.Gender != nil {
+			stored.Gender = *upd.Gender
+		}
+		if upd.DateOfBirth != nil {
+			stored.DateOfBirth = *upd.DateOfBirth
+		}
+		return &repository.OutboxEvent{Type: repository.EventUserUpdated}, nil
+	})
+}
+
+// Block marks the user as blocked and records the block details.
+func (r *UserRepository) Block(ctx context.Context, id string, block repository.BlockDetails, expectedVersion int64) (*repository.User, error) {
+	return r.mutate(ctx, id, expectedVersion, func(stored *repository.User) (*repository.OutboxEvent, error) {
+		stored.IsBlocked = true
+		stored.BlockReason = block.Reason
+		stored.BlockedBy = block.BlockedBy
+		stored.BlockedAt = now()
+		stored.BlockExpiresAt = block.ExpiresAt
+		return &repository.OutboxEvent{Type: repository.EventUserBlocked}, nil
+	})
+}
+
+// Unblock clears the blocked flag and the block details of the user.
+func (r *UserRepository) Unblock(ctx context.Context, id string, expectedVersion int64) (*repository.User, error) {
+	return r.mutate(ctx, id, expectedVersion, func(stored *repository.User) (*repository.OutboxEvent, error) {
+		stored.IsBlocked = false
+		stored.BlockReason = ""
+		stored.BlockedBy = ""
+		stored.BlockedAt = time.Time{}
+		stored.BlockExpiresAt = time.Time{}
+		return &repository.OutboxEvent{Type: repository.EventUserUnblocked}, nil
+	})
+}
+
+// ListExpiredBlocks returns the blocked users whose block expires at or
+// before now.
+func (r *UserRepository) ListExpiredBlocks(ctx context.Context, now time.Time) ([]*repository.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []*repository.User
+	for id, stored := range r.users {
+		if _, deleted := r.deleted[id]; deleted || !stored.IsBlocked {
+			continue
+		}
+		if stored.BlockExpiresAt.IsZero() || stored.BlockExpiresAt.After(now) {
+			continue
+		}
+		u := *stored
+		users = append(users, &u)
+	}
+	return users, nil
+}
+
+// StageContact records the changed phone number and email as pending.
+func (r *UserRepository) StageContact(ctx context.Context, id, phoneNumber, email string, expectedVersion int64) (*repository.User, error) {
+	return r.mutate(ctx, id, expectedVersion, func(stored *repository.User) (*repository.OutboxEvent, error) {
+		if stored.IsBlocked {
+			return nil, repository.ErrUserBlocked
+		}
+		if err := r.checkContact(id, phoneNumber, email); err != nil {
+			return nil, err
+		}
+		stored.PendingPhoneNumber, stored.PendingEmail = "", ""
+		if stored.PhoneNumber != phoneNumber {
+			stored.PendingPhoneNumber = phoneNumber
+		}
+		if stored.Email != email {
+			stored.PendingEmail = email
+		}
+		return &repository.OutboxEvent{Type: repository.EventUserUpdated}, nil
+	})
+}
+
+// ConfirmContact marks the channel's contact as verified, first moving a
+// matching pending contact into place and re-indexing it.
+func (r *UserRepository) ConfirmContact(ctx context.Context, id string, channel repository.ContactChannel, value string) (*repository.User, error) {
+	return r.mutate(ctx, id, 0, func(stored *repository.User) (*repository.OutboxEvent, error) {
+		current, pending, verified, index := &stored.Email, &stored.PendingEmail, &stored.EmailVerified, r.byEmail
+		checkPhone, checkEmail := "", value
+		if channel == repository.ContactChannelPhone {
+			current, pending, verified, index = &stored.PhoneNumber, &stored.PendingPhoneNumber, &stored.PhoneVerified, r.byPhone
+			checkPhone, checkEmail = value, ""
+		}
+
+		e := &repository.OutboxEvent{Type: repository.EventUserUpdated}
+		switch {
+		case *pending != "" && *pending == value:
+			if err := r.checkContact(id, checkPhone, checkEmail); err != nil {
+				return nil, err
+			}
+			e = &repository.OutboxEvent{Type: repository.EventContactChanged, Channel: channel, PreviousContact: *current}
+			delete(index, *current)
+			index[value] = id
+			*current, *pending = value, ""
+		case *current != value:
+			return nil, repository.ErrContactChanged
+		}
+		*verified = true
+		return e, nil
+	})
+}
+
+// Delete soft-deletes the user, keeping its contact details indexed.
+func (r *UserRepository) Delete(ctx context.Context, id string, expectedVersion int64) error {
+	_, err := r.mutate(ctx, id, expectedVersion, func(stored *repository.User) (*repository.OutboxEvent, error) {
+		r.deleted[id] = now()
+		return nil, nil
+	})
+	return err
+}
+
+// Purge removes the user, soft-deleted or not, and its lookup index entries.
+func (r *UserRepository) Purge(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	previous := *stored
+	r.unindexContact(stored)
+	delete(r.users, id)
+	delete(r.deleted, id)
+
+	kept := r.outbox[:0]
+	for _, e := range r.outbox {
+		if e.User.ID != id {
+			kept = append(kept, e)
+		}
+	}
+	r.outbox = kept
+	repository.RecordPrevious(ctx, &previous)
+	return nil
+}
+
+// PendingEvents returns the oldest unpublished events.
+func (r *UserRepository) PendingEvents(ctx context.Context, limit int) ([]*repository.OutboxEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []*repository.OutboxEvent
+	for i := 0; i < len(r.outbox) && len(events) < limit; i++ {
+		e := r.outbox[i]
+		events = append(events, &e)
+	}
+	return events, nil
+}
+
+// RemoveEvents drops published events from the outbox.
+func (r *UserRepository) RemoveEvents(ctx context.Context, events []*repository.OutboxEvent) error {
+	published := make(map[string]bool, len(events))
+	for _, e := range events {
+		published[e.ID] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.outbox[:0]
+	for _, e := range r.outbox {
+		if !published[e.ID] {
+			kept = append(kept, e)
+		}
+	}
+	r.outbox = kept
+	return nil
+}
+
+// mutate applies fn to the stored user under the write lock, bumps UpdatedAt
+// and Version, records the event returned by fn, if any, and returns a copy of
+// the result. A non-zero expectedVersion must match the stored version.
+func (r *UserRepository) mutate(ctx context.Context, id string, expectedVersion int64, fn func(stored *repository.User) (*repository.OutboxEvent, error)) (*repository.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[id]
+	if _, deleted := r.deleted[id]; !ok || deleted {
+		return nil, repository.ErrNotFound
+	}
+	if expectedVersion != 0 && stored.Version != expectedVersion {
+		return nil, repository.ErrVersionMismatch
+	}
+	previous := *stored
+	e, err := fn(stored)
+	if err != nil {
+		return nil, err
+	}
+	repository.RecordPrevious(ctx, &previous)
+	stored.UpdatedAt = now()
+	stored.Version++
+	if e != nil {
+		r.record(e, stored)
+	}
+	u := *stored
+	return &u, nil
+}
+
+// record appends e to the outbox with a snapshot of u. The caller must hold
+// the write lock.
+func (r *UserRepository) record(e *repository.OutboxEvent, u *repository.User) {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		id = uuid.New()
+	}
+	e.ID, e.User, e.OccurredAt = id.String(), *u, u.UpdatedAt
+	r.outbox = append(r.outbox, *e)
+}
+
+func (r *UserRepository) get(id string) (*repository.User, error) {
+	stored, ok := r.users[id]
+	if _, deleted := r.deleted[id]; !ok || deleted {
+		return nil, repository.ErrNotFound
+	}
+	u := *stored
+	return &u, nil
+}
+
+// checkContact reports whether the phone number or email is owned by a user
+// other than id.
+func (r *UserRepository) checkContact(id, phoneNumber, email string) error {
+	if owner, ok := r.byEmail[email]; ok && email != "" && owner != id {
+		return repository.ErrDuplicateEmail
+	}
+	if owner, ok := r.byPhone[phoneNumber]; ok && phoneNumber != "" && owner != id {
+		return repository.ErrDuplicatePhoneNumber
+	}
+	return nil
+}
+
+func (r *UserRepository) indexContact(u *repository.User) {
+	if u.Email != "" {
+		r.byEmail[u.Email] = u.ID
+	}
+	if u.PhoneNumber != "" {
+		r.byPhone[u.PhoneNumber] = u.ID
+	}
+}
+
+func (r *UserRepository) unindexContact(u *repository.User) {
+	delete(r.byEmail, u.Email)
+	delete(r.byPhone, u.PhoneNumber)
+}
+
+// now returns the current time at the millisecond precision the Cassandra
+// repository stores, so that both backends return the same timestamps.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,275 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"user_service/internal/repository"
+)
+
+func newUser(n int) *repository.User {
+	now := time.Now().UTC()
+	return &repository.User{
+		ID:          fmt.Sprintf("00000000-0000-0000-0000-%012d", n),
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		Gender:      "Female",
+		DateOfBirth: "1990-01-02",
+		PhoneNumber: fmt.Sprintf("+4915100000%03d", n),
+		Email:       fmt.Sprintf("user%d@example.com", n),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Version:     1,
+	}
+}
+
+func mustCreate(t *testing.T, r *UserRepository, u *repository.User) {
+	t.Helper()
+	if err := r.Create(context.Background(), u); err != nil {
+		t.Fatalf("Create(%s): %v", u.ID, err)
+	}
+}
+
+func TestCreateConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(u *repository.User)
+		wantErr error
+	}{
+		{"distinct contacts", func(u *repository.User) {}, nil},
+		{"duplicate email", func(u *repository.User) { u.Email = "user1@example.com" }, repository.ErrDuplicateEmail},
+		{"duplicate phone number", func(u *repository.User) { u.PhoneNumber = "+4915100000001" }, repository.ErrDuplicatePhoneNumber},
+		{"no phone number", func(u *repository.User) { u.PhoneNumber = "" }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewUserRepository()
+			mustCreate(t, r, newUser(1))
+
+			u := newUser(2)
+			tt.modify(u)
+			if err := r.Create(context.Background(), u); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create = %v, want %v", err, tt.wantErr)
+			}
+			_, err := r.Get(context.Background(), u.ID)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Get after Create = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, repository.ErrNotFound) {
+				t.Errorf("Get after failed Create = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestVersionChecks(t *testing.T) {
+	name := "Zoe"
+	mutations := map[string]func(r *UserRepository, id string, version int64) (*repository.User, error){
+		"Update": func(r *UserRepository, id string, version int64) (*repository.User, error) {
+			return r.Update(context.Background(), id, repository.ProfileUpdate{FirstName: &name}, version)
+		},
+		"Block": func(r *UserRepository, id string, version int64) (*repository.User, error) {
+			return r.Block(context.Background(), id, repository.BlockDetails{Reason: "spam"}, version)
+		},
+		"Unblock": func(r *UserRepository, id string, version int64) (*repository.User, error) {
+			return r.Unblock(context.Background(), id, version)
+		},
+		"StageContact": func(r *UserRepository, id string, version int64) (*repository.User, error) {
+			return r.StageContact(context.Background(), id, "+4915199999999", "new@example.com", version)
+		},
+		"Delete": func(r *UserRepository, id string, version int64) (*repository.User, error) {
+			return nil, r.Delete(context.Background(), id, version)
+		},
+	}
+	tests := []struct {
+		name    string
+		version int64
+		wantErr error
+	}{
+		{"unchecked", 0, nil},
+		{"current version", 3, nil},
+		{"stale version", 2, repository.ErrVersionMismatch},
+		{"future version", 4, repository.ErrVersionMismatch},
+	}
+	for method, mutate := range mutations {
+		for _, tt := range tests {
+			t.Run(method+"/"+tt.name, func(t *testing.T) {
+				r := NewUserRepository()
+				u := newUser(1)
+				u.Version = 3
+				mustCreate(t, r, u)
+
+				got, err := mutate(r, u.ID, tt.version)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("%s = %v, want %v", method, err, tt.wantErr)
+				}
+				if err != nil {
+					if stored, _ := r.Get(context.Background(), u.ID); stored.Version != 3 {
+						t.Errorf("version after failed %s = %d, want 3", method, stored.Version)
+					}
+					return
+				}
+				if got != nil && got.Version != 4 {
+					t.Errorf("version after %s = %d, want 4", method, got.Version)
+				}
+			})
+		}
+	}
+
+	t.Run("unknown id", func(t *testing.T) {
+		r := NewUserRepository()
+		for method, mutate := range mutations {
+			if _, err := mutate(r, newUser(9).ID, 0); !errors.Is(err, repository.ErrNotFound) {
+				t.Errorf("%s of unknown user = %v, want ErrNotFound", method, err)
+			}
+		}
+	})
+}
+
+func TestBlockedUserChanges(t *testing.T) {
+	ctx := context.Background()
+	r := NewUserRepository()
+	u := newUser(1)
+	mustCreate(t, r, u)
+	if _, err := r.Block(ctx, u.ID, repository.BlockDetails{Reason: "spam"}, 0); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+
+	name := "Zoe"
+	if _, err := r.Update(ctx, u.ID, repository.ProfileUpdate{FirstName: &name}, 0); !errors.Is(err, repository.ErrUserBlocked) {
+		t.Errorf("Update of blocked user = %v, want ErrUserBlocked", err)
+	}
+	if _, err := r.StageContact(ctx, u.ID, u.PhoneNumber, "new@example.com", 0); !errors.Is(err, repository.ErrUserBlocked) {
+		t.Errorf("StageContact of blocked user = %v, want ErrUserBlocked", err)
+	}
+
+	if _, err := r.Unblock(ctx, u.ID, 0); err != nil {
+		t.Fatalf("Unblock: %v", err)
+	}
+	if _, err := r.Update(ctx, u.ID, repository.ProfileUpdate{FirstName: &name}, 0); err != nil {
+		t.Errorf("Update of unblocked user = %v", err)
+	}
+}
+
+func TestTimestampPrecision(t *testing.T) {
+	ctx := context.Background()
+	r := NewUserRepository()
+	u := newUser(1)
+	mustCreate(t, r, u)
+
+	blocked, err := r.Block(ctx, u.ID, repository.BlockDetails{Reason: "spam"}, 0)
+	if err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+	// The Cassandra repository stores milliseconds; the memory one must
+	// return the same timestamps.
+	for name, ts := range map[string]time.Time{"blocked_at": blocked.BlockedAt, "updated_at": blocked.UpdatedAt} {
+		if ts.IsZero() || !ts.Equal(ts.Truncate(time.Millisecond)) || ts.Location() != time.UTC {
+			t.Errorf("%s = %s, want a UTC time truncated to the millisecond", name, ts.Format(time.RFC3339Nano))
+		}
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	ctx := context.Background()
+	r := NewUserRepository()
+	u := newUser(1)
+	mustCreate(t, r, u)
+	mustCreate(t, r, newUser(2))
+
+	if err := r.Delete(ctx, u.ID, 0); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := r.Get(ctx, u.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Get = %v, want ErrNotFound", err)
+	}
+	if _, err := r.GetByEmail(ctx, u.Email); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByEmail = %v, want ErrNotFound", err)
+	}
+	if _, err := r.GetByPhoneNumber(ctx, u.PhoneNumber); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByPhoneNumber = %v, want ErrNotFound", err)
+	}
+	if err := r.Delete(ctx, u.ID, 0); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("second Delete = %v, want ErrNotFound", err)
+	}
+	users, _, err := r.List(ctx, repository.ListOptions{PageSize: 10})
+	if err != nil || len(users) != 1 || users[0].ID == u.ID {
+		t.Errorf("List = %d users, %v; want only the remaining user", len(users), err)
+	}
+
+	// The contacts of a soft-deleted user stay reserved until it is purged.
+	again := newUser(3)
+	again.Email = u.Email
+	if err := r.Create(ctx, again); !errors.Is(err, repository.ErrDuplicateEmail) {
+		t.Errorf("Create with email of deleted user = %v, want ErrDuplicateEmail", err)
+	}
+	if err := r.Purge(ctx, u.ID); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if err := r.Create(ctx, again); err != nil {
+		t.Errorf("Create with email of purged user = %v", err)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	ctx := context.Background()
+	r := NewUserRepository()
+	for i := 1; i <= 5; i++ {
+		u := newUser(i)
+		if i%2 == 0 {
+			u.Gender = "Male"
+		}
+		mustCreate(t, r, u)
+	}
+	blocked := true
+	if _, err := r.Block(ctx, newUser(3).ID, repository.BlockDetails{}, 0); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		opts      repository.ListOptions
+		wantPages [][]int
+	}{
+		{"all in one page", repository.ListOptions{PageSize: 10}, [][]int{{1, 2, 3, 4, 5}}},
+		{"exact pages", repository.ListOptions{PageSize: 5}, [][]int{{1, 2, 3, 4, 5}}},
+		{"several pages", repository.ListOptions{PageSize: 2}, [][]int{{1, 2}, {3, 4}, {5}}},
+		// Filtered pages are cut by the unfiltered order, so the last match
+		// can be followed by an empty page.
+		{"gender filter", repository.ListOptions{PageSize: 1, Gender: "Male"}, [][]int{{2}, {4}, {}}},
+		{"blocked filter", repository.ListOptions{PageSize: 2, IsBlocked: &blocked}, [][]int{{3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			for page, want := range tt.wantPages {
+				users, next, err := r.List(ctx, opts)
+				if err != nil {
+					t.Fatalf("page %d: %v", page, err)
+				}
+				var got []string
+				for _, u := range users {
+					got = append(got, u.ID)
+				}
+				var wantIDs []string
+				for _, n := range want {
+					wantIDs = append(wantIDs, newUser(n).ID)
+				}
+				if fmt.Sprint(got) != fmt.Sprint(wantIDs) {
+					t.Errorf("page %d = %v, want %v", page, got, wantIDs)
+				}
+				last := page == len(tt.wantPages)-1
+				if last != (next == "") {
+					t.Fatalf("page %d: next page token %q, want one only before the last page", page, next)
+				}
+				opts.PageToken = next
+			}
+		})
+	}
+
+	if _, _, err := r.List(ctx, repository.ListOptions{PageSize: 2, PageToken: "not base64!"}); !errors.Is(err, repository.ErrInvalidPageToken) {
+		t.Errorf("List with invalid token = %v, want ErrInvalidPageToken", err)
+	}
+}
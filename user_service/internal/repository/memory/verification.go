@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+	"user_service/internal/repository"
+)
+
+// VerificationStore is an in-process implementation of
+// repository.VerificationStore.
+type VerificationStore struct {
+	mu            sync.Mutex
+	verifications map[verificationKey]repository.Verification
+}
+
+type verificationKey struct {
+	userID  string
+	channel repository.ContactChannel
+}
+
+var _ repository.VerificationStore = (*VerificationStore)(nil)
+
+func NewVerificationStore() *VerificationStore {
+	return &VerificationStore{verifications: make(map[verificationKey]repository.Verification)}
+}
+
+// Put stores v, replacing the pending verification of the same user and
+// channel.
+func (s *VerificationStore) Put(ctx context.Context, v *repository.Verification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.verifications[verificationKey{v.UserID, v.Channel}] = *v
+	return nil
+}
+
+// Get returns the pending verification, dropping it once it has expired.
+func (s *VerificationStore) Get(ctx context.Context, userID string, channel repository.ContactChannel) (*repository.Verification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := verificationKey{userID, channel}
+	v, ok := s.verifications[key]
+	if !ok {
+		return nil, repository.ErrVerificationNotFound
+	}
+	if !v.ExpiresAt.After(time.Now()) {
+		delete(s.verifications, key)
+		return nil, repository.ErrVerificationNotFound
+	}
+	return &v, nil
+}
+
+// IncrementAttempts counts a confirmation attempt under the lock.
+func (s *VerificationStore) IncrementAttempts(ctx context.Context, userID string, channel repository.ContactChannel) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := verificationKey{userID, channel}
+	v, ok := s.verifications[key]
+	if !ok {
+		return 0, repository.ErrVerificationNotFound
+	}
+	v.Attempts++
+	s.verifications[key] = v
+	return v.Attempts, nil
+}
+
+// Delete removes the pending verification, if any.
+func (s *VerificationStore) Delete(ctx context.Context, userID string, channel repository.ContactChannel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.verifications, verificationKey{userID, channel})
+	return nil
+}
@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"user_service/internal/repository"
+)
+
+// WebhookStore is an in-process implementation of repository.WebhookStore.
+type WebhookStore struct {
+	mu         sync.RWMutex
+	webhooks   map[string]repository.Webhook
+	deliveries map[string][]*repository.Delivery
+}
+
+var _ repository.WebhookStore = (*WebhookStore)(nil)
+
+func NewWebhookStore() *WebhookStore {
+	return &WebhookStore{
+		webhooks:   make(map[string]repository.Webhook),
+		deliveries: make(map[string][]*repository.Delivery),
+	}
+}
+
+func (s *WebhookStore) CreateWebhook(ctx context.Context, w *repository.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *w
+	stored.EventTypes = append([]repository.EventType(nil), w.EventTypes...)
+	s.webhooks[w.ID] = stored
+	return nil
+}
+
+func (s *WebhookStore) GetWebhook(ctx context.Context, id string) (*repository.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	w, ok := s.webhooks[id]
+	if !ok {
+		return nil, repository.ErrWebhookNotFound
+	}
+	return &w, nil
+}
+
+func (s *WebhookStore) ListWebhooks(ctx context.Context) ([]*repository.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhooks := make([]*repository.Webhook, 0, len(s.webhooks))
+	for _, w := range s.webhooks {
+		w := w
+		webhooks = append(webhooks, &w)
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+	return webhooks, nil
+}
+
+func (s *WebhookStore) DeleteWebhook(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[id]; !ok {
+		return repository.ErrWebhookNotFound
+	}
+	delete(s.webhooks, id)
+	return nil
+}
+
+// EnqueueDelivery appends the delivery to the webhook's log unless the event
+// was already enqueued for it.
+func (s *WebhookStore) EnqueueDelivery(ctx context.Context, d *repository.Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.deliveries[d.WebhookID] {
+		if stored.EventID == d.EventID {
+			return nil
+		}
+	}
+	s.deliveries[d.WebhookID] = append(s.deliveries[d.WebhookID], copyDelivery(d))
+	return nil
+}
+
+// DueDeliveries returns the due pending deliveries, oldest first.
+func (s *WebhookStore) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*repository.Delivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var due []*repository.Delivery
+	for _, log := range s.deliveries {
+		for _, d := range log {
+			if d.Status == repository.DeliveryPending && !d.NextAttemptAt.After(now) {
+				due = append(due, copyDelivery(d))
+			}
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (s *WebhookStore) UpdateDelivery(ctx context.Context, d *repository.Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, stored := range s.deliveries[d.WebhookID] {
+		if stored.EventID == d.EventID {
+			s.deliveries[d.WebhookID][i] = copyDelivery(d)
+			return nil
+		}
+	}
+	return nil
+}
+
+// ListDeliveries returns the webhook's deliveries in reverse order of
+// enqueueing. The page token is the base64 encoded number of matching
+// deliveries already returned.
+func (s *WebhookStore) ListDeliveries(ctx context.Context, q repository.DeliveryQuery) ([]*repository.Delivery, string, error) {
+	offset := 0
+	if q.PageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(q.PageToken)
+		if err != nil {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+		if offset, err = strconv.Atoi(string(b)); err != nil || offset < 0 {
+			return nil, "", repository.ErrInvalidPageToken
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	log := s.deliveries[q.WebhookID]
+	var (
+		deliveries []*repository.Delivery
+		matched    int
+	)
+	for i := len(log) - 1; i >= 0; i-- {
+		d := log[i]
+		if q.Status != "" && d.Status != q.Status {
+			continue
+		}
+		matched++
+		if matched <= offset {
+			continue
+		}
+		if len(deliveries) == q.PageSize {
+			return deliveries, base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset + len(deliveries)))), nil
+		}
+		deliveries = append(deliveries, copyDelivery(d))
+	}
+	return deliveries, "", nil
+}
+
+func copyDelivery(d *repository.Delivery) *repository.Delivery {
+	c := *d
+	c.Attempts = append([]repository.DeliveryAttempt(nil), d.Attempts...)
+	return &c
+}
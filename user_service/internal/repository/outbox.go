@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// EventType is the kind of change recorded by an OutboxEvent.
+type EventType string
+
+const (
+	EventUserCreated    EventType = "UserCreated"
+	EventUserUpdated    EventType = "UserUpdated"
+	EventUserBlocked    EventType = "UserBlocked"
+	EventUserUnblocked  EventType = "UserUnblocked"
+	EventContactChanged EventType = "ContactChanged"
+)
+
+// OutboxEvent is a domain event recorded by the UserRepository in the same
+// write as the change it describes, and kept until it has been published.
+type OutboxEvent struct {
+	// ID is a time UUID identifying the event. The events of a user are
+	// ordered by User.Version, not by ID.
+	ID   string
+	Type EventType
+	// User is the state of the user after the change.
+	User User
+	// Channel and PreviousContact are set for EventContactChanged only.
+	Channel         ContactChannel
+	PreviousContact string
+	OccurredAt      time.Time
+}
+
+// Outbox gives the event relay access to the events recorded by a
+// UserRepository. Create, Update, Block, Unblock, StageContact and
+// ConfirmContact each record one event atomically with their write; Delete
+// and Purge record none, and Purge drops the unpublished events of the user.
+type Outbox interface {
+	// PendingEvents returns up to limit unpublished events. The events of a
+	// user are returned in the order of the user versions they were recorded
+	// at.
+	PendingEvents(ctx context.Context, limit int) ([]*OutboxEvent, error)
+
+	// RemoveEvents removes published events. Events that were not removed
+	// are returned again by PendingEvents, so delivery is at least once.
+	RemoveEvents(ctx context.Context, events []*OutboxEvent) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import "context"
+
+// previousKey is the context key of the slot filled by RecordPrevious.
+type previousKey struct{}
+
+// TrackPrevious returns a context in which the mutating methods of a
+// UserRepository report the user as it was right before their write: the
+// version their compare-and-set replaced. The returned function yields that
+// user once the write has succeeded, or nil. Unlike a separate read before
+// the write, it cannot observe a version written concurrently by someone
+// else. Only the first write made with the context is reported.
+func TrackPrevious(ctx context.Context) (context.Context, func() *User) {
+	slot := new(*User)
+	return context.WithValue(ctx, previousKey{}, slot), func() *User { return *slot }
+}
+
+// RecordPrevious is called by UserRepository implementations after a
+// successful write with a copy of the user the write replaced.
+func RecordPrevious(ctx context.Context, u *User) {
+	if slot, ok := ctx.Value(previousKey{}).(**User); ok && *slot == nil {
+		*slot = u
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when no user matches the given id or lookup key.
+	ErrNotFound = errors.New("user not found")
+
+	// ErrDuplicateEmail is returned when the email is already owned by another user.
+	ErrDuplicateEmail = errors.New("email already in use")
+
+	// ErrDuplicatePhoneNumber is returned when the phone number is already owned
+	// by another user.
+	ErrDuplicatePhoneNumber = errors.New("phone number already in use")
+
+	// ErrInvalidPageToken is returned by List when the page token was not
+	// produced by the same backend.
+	ErrInvalidPageToken = errors.New("invalid page token")
+
+	// ErrVersionMismatch is returned by mutations when the stored version
+	// differs from the version the caller expected.
+	ErrVersionMismatch = errors.New("user version mismatch")
+
+	// ErrUserBlocked is returned when an operation is refused because the user
+	// is blocked.
+	ErrUserBlocked = errors.New("user is blocked")
+
+	// ErrContactChanged is returned by ConfirmContact when neither the current
+	// nor the pending contact has the value that was verified.
+	ErrContactChanged = errors.New("contact changed since verification started")
+)
+
+// User is the stored representation of a user record.
+type User struct {
+	ID          string
+	FirstName   string
+	LastName    string
+	Gender      string
+	DateOfBirth string
+	PhoneNumber string
+	Email       string
+	IsBlocked   bool
+
+	// EmailVerified and PhoneVerified report whether the current email and
+	// phone number have been confirmed. They are cleared when the contact
+	// changes.
+	EmailVerified bool
+	PhoneVerified bool
+
+	// PendingEmail and PendingPhoneNumber are contact changes waiting for
+	// verification. They are not indexed for lookups until ConfirmContact
+	// moves them to Email and PhoneNumber.
+	PendingEmail       string
+	PendingPhoneNumber string
+
+	// BlockReason, BlockedBy and BlockedAt describe the current block and are
+	// empty while the user is not blocked. BlockExpiresAt is zero for blocks
+	// without an expiry.
+	BlockReason    string
+	BlockedBy      string
+	BlockedAt      time.Time
+	BlockExpiresAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Version starts at 1 and is incremented by every successful mutation.
+	Version int64
+}
+
+// ListOptions selects and paginates the users returned by List.
+type ListOptions struct {
+	// PageSize is the maximum number of users to return.
+	PageSize int
+	// PageToken is an opaque cursor returned by a previous List call.
+	PageToken string
+	// IsBlocked, when set, only matches users with this blocked state.
+	IsBlocked *bool
+	// Gender, when non-empty, only matches users with this gender.
+	Gender string
+}
+
+// BlockDetails describes why, by whom and until when a user is blocked.
+type BlockDetails struct {
+	Reason    string
+	BlockedBy string
+	// ExpiresAt is the time the block is lifted automatically; zero blocks
+	// the user until Unblock is called.
+	ExpiresAt time.Time
+}
+
+// ProfileUpdate holds the profile fields to change. Nil fields keep their
+// stored value.
+type ProfileUpdate struct {
+	FirstName   *string
+	LastName    *string
+	Gender      *string
+	DateOfBirth *string
+}
+
+// UserRepository abstracts the storage backend used by the user service.
+//
+// Methods that act on an existing user return ErrNotFound when no user has the
+// given id; they never create partial records for unknown ids. Every successful
+// mutation sets UpdatedAt and increments Version. Mutations take the version
+// the caller expects the user to be at and fail with ErrVersionMismatch if it
+// differs; an expected version of zero skips the check. Successful writes,
+// including Delete and Purge, pass the user they replaced to RecordPrevious.
+type UserRepository interface {
+	// Create stores a new user. The caller is responsible for assigning the ID,
+	// timestamps and initial version.
+	Create(ctx context.Context, u *User) error
+
+	// Get returns the user with the given id.
+	Get(ctx context.Context, id string) (*User, error)
+
+	// GetByEmail returns the user owning the given email address.
+	GetByEmail(ctx context.Context, email string) (*User, error)
+
+	// GetByPhoneNumber returns the user owning the given phone number.
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*User, error)
+
+	// List returns a page of users matching opts and the token of the next
+	// page, which is empty once there are no more results. A page may hold
+	// fewer than opts.PageSize users even when more results follow.
+	List(ctx context.Context, opts ListOptions) ([]*User, string, error)
+
+	// Update changes the non-nil profile fields of the user and returns the
+	// stored user. It fails with ErrUserBlocked while the user is blocked.
+	Update(ctx context.Context, id string, upd ProfileUpdate, expectedVersion int64) (*User, error)
+
+	// Block marks the user as blocked with the given details and returns the
+	// stored user. Blocking a blocked user replaces the details of its block.
+	Block(ctx context.Context, id string, block BlockDetails, expectedVersion int64) (*User, error)
+
+	// Unblock clears the blocked flag and the block details and returns the
+	// stored user.
+	Unblock(ctx context.Context, id string, expectedVersion int64) (*User, error)
+
+	// ListExpiredBlocks returns the blocked users whose block expires at or
+	// before now.
+	ListExpiredBlocks(ctx context.Context, now time.Time) ([]*User, error)
+
+	// StageContact records the phone number and email that differ from the
+	// current ones as pending and returns the stored user. A value equal to
+	// the current one clears the pending change of that contact. Values owned
+	// by another user are rejected up front, but are only reserved by
+	// ConfirmContact. It fails with ErrUserBlocked while the user is blocked.
+	StageContact(ctx context.Context, id, phoneNumber, email string, expectedVersion int64) (*User, error)
+
+	// ConfirmContact marks the channel's contact as verified and returns the
+	// stored user. If value is the pending contact, it replaces the current
+	// one and the lookup entries are switched over. It fails with
+	// ErrContactChanged if value is neither the current nor the pending
+	// contact.
+	ConfirmContact(ctx context.Context, id string, channel ContactChannel, value string) (*User, error)
+
+	// Delete soft-deletes the user. Soft-deleted users are reported as not
+	// found by every other method except Purge, but keep their email and
+	// phone number reserved.
+	Delete(ctx context.Context, id string, expectedVersion int64) error
+
+	// Purge permanently removes the user, soft-deleted or not, together with
+	// its email and phone number lookup entries.
+	Purge(ctx context.Context, id string) error
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrVerificationNotFound is returned when a user has no pending verification
+// for a channel.
+var ErrVerificationNotFound = errors.New("no pending contact verification")
+
+// ContactChannel names one of the contact fields of a user.
+type ContactChannel string
+
+const (
+	ContactChannelEmail ContactChannel = "EMAIL"
+	ContactChannelPhone ContactChannel = "PHONE"
+)
+
+// Verification is a one-time code sent to a user's contact, waiting to be
+// confirmed.
+type Verification struct {
+	UserID  string
+	Channel ContactChannel
+	// Destination is the email or phone number the code was sent to.
+	Destination string
+	// CodeHash is the hash of the code; the code itself is never stored.
+	CodeHash string
+	// Attempts counts the confirmations tried so far.
+	Attempts  int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// VerificationStore keeps the pending contact verifications, at most one per
+// user and channel.
+type VerificationStore interface {
+	// Put stores v, replacing any pending verification of the same user and
+	// channel. Expired verifications may be removed by the store.
+	Put(ctx context.Context, v *Verification) error
+
+	// Get returns the pending verification of the user and channel.
+	Get(ctx context.Context, userID string, channel ContactChannel) (*Verification, error)
+
+	// IncrementAttempts atomically counts a confirmation attempt and returns
+	// the number of attempts made so far, including this one.
+	IncrementAttempts(ctx context.Context, userID string, channel ContactChannel) (int, error)
+
+	// Delete removes the pending verification, if any.
+	Delete(ctx context.Context, userID string, channel ContactChannel) error
+}
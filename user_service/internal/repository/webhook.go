@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrWebhookNotFound is returned when no webhook exists with the requested ID.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// Webhook is a subscription of a URL to user events.
+type Webhook struct {
+	ID         string
+	URL        string
+	EventTypes []EventType
+	// Secret is the HMAC-SHA256 key the deliveries are signed with.
+	Secret    string
+	CreatedAt time.Time
+}
+
+// Subscribed reports whether the webhook receives events of type t.
+func (w *Webhook) Subscribed(t EventType) bool {
+	for _, et := range w.EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus is the state of a Delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryPending    DeliveryStatus = "PENDING"
+	DeliverySucceeded  DeliveryStatus = "SUCCEEDED"
+	DeliveryDeadLetter DeliveryStatus = "DEAD_LETTER"
+)
+
+// DeliveryAttempt is a single POST of a delivery to its webhook.
+type DeliveryAttempt struct {
+	AttemptedAt time.Time     `json:"attempted_at"`
+	StatusCode  int           `json:"status_code,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	Duration    time.Duration `json:"duration"`
+}
+
+// Delivery is the delivery of one event to one webhook. It is identified by
+// the webhook and event IDs, so an event is enqueued at most once per webhook.
+type Delivery struct {
+	WebhookID string
+	EventID   string
+	UserID    string
+	EventType EventType
+	// Payload is the JSON encoded event posted to the webhook.
+	Payload   []byte
+	Status    DeliveryStatus
+	CreatedAt time.Time
+	// NextAttemptAt is when a pending delivery is due.
+	NextAttemptAt time.Time
+	Attempts      []DeliveryAttempt
+}
+
+// DeliveryQuery selects and paginates the deliveries returned by
+// WebhookStore.ListDeliveries.
+type DeliveryQuery struct {
+	WebhookID string
+	// Status only returns deliveries in this status; empty returns all.
+	Status DeliveryStatus
+	// PageSize is the maximum number of deliveries to return.
+	PageSize int
+	// PageToken is an opaque cursor returned by a previous ListDeliveries
+	// call.
+	PageToken string
+}
+
+// WebhookStore keeps the webhook subscriptions and their delivery log. A store
+// may drop succeeded and dead-lettered deliveries after a retention period.
+type WebhookStore interface {
+	CreateWebhook(ctx context.Context, w *Webhook) error
+
+	// GetWebhook returns ErrWebhookNotFound for unknown IDs.
+	GetWebhook(ctx context.Context, id string) (*Webhook, error)
+
+	// ListWebhooks returns every webhook, oldest first.
+	ListWebhooks(ctx context.Context) ([]*Webhook, error)
+
+	// DeleteWebhook removes the webhook. Its deliveries are kept until the
+	// store's retention drops them. It returns ErrWebhookNotFound for unknown
+	// IDs.
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// EnqueueDelivery stores a new delivery, unless one already exists for
+	// the same webhook and event.
+	EnqueueDelivery(ctx context.Context, d *Delivery) error
+
+	// DueDeliveries returns up to limit pending deliveries whose
+	// NextAttemptAt is not after now.
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*Delivery, error)
+
+	// UpdateDelivery stores the status, next attempt time and attempts of
+	// the delivery.
+	UpdateDelivery(ctx context.Context, d *Delivery) error
+
+	// ListDeliveries returns a page of the webhook's deliveries matching q,
+	// newest event first, and the token of the next page, which is empty once
+	// there are no more results.
+	ListDeliveries(ctx context.Context, q DeliveryQuery) ([]*Delivery, string, error)
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"log"
+	"path"
+	"strings"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/repository"
+	"user_service/protogen/user"
+)
+
+const (
+	// actorKey is the metadata key identifying the caller. It is set by the
+	// authenticating proxy in front of the service, which must also keep
+	// clients from reaching the gRPC port directly, and forwarded by the REST
+	// gateway from the X-Actor header when http_details.trust_actor_header is
+	// set.
+	actorKey = "x-actor"
+
+	// requestIDKey is the metadata key carrying the request id, forwarded by
+	// the REST gateway from the X-Request-Id header.
+	requestIDKey = "x-request-id"
+
+	// anonymousActor is recorded when a request carries no actor.
+	anonymousActor = "anonymous"
+)
+
+// auditedMethods are the mutating RPCs recorded in the audit log.
+var auditedMethods = map[string]bool{
+	user.UserService_CreateUser_FullMethodName:                 true,
+	user.UserService_UpdateUser_FullMethodName:                 true,
+	user.UserService_BlockUser_FullMethodName:                  true,
+	user.UserService_UnblockUser_FullMethodName:                true,
+	user.UserService_UpdateContact_FullMethodName:              true,
+	user.UserService_ConfirmContactVerification_FullMethodName: true,
+	user.UserService_DeleteUser_FullMethodName:                 true,
+	user.UserService_PurgeUser_FullMethodName:                  true,
+}
+
+// WithAudit records every successful mutating RPC in store and enables
+// ListAuditEvents. The recording itself is done by AuditInterceptor.
+func WithAudit(store repository.AuditStore) Option {
+	return func(s *UserServiceServer) {
+		s.audit = store
+	}
+}
+
+// replayedKey is the context key of the flag a handler sets through
+// markReplayed when it answers with a stored response instead of mutating.
+type replayedKey struct{}
+
+// AuditInterceptor is a unary server interceptor recording the actor, RPC
+// name, request id and field changes of every successful mutating RPC. The
+// changes are computed against the user the handler's write replaced, as
+// reported by the repository through repository.TrackPrevious; users that did
+// not exist before are diffed against an empty user. Replayed idempotent
+// requests changed nothing and are not recorded.
+func (s *UserServiceServer) AuditInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.audit == nil || !auditedMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	handlerCtx, previous := repository.TrackPrevious(ctx)
+	replayed := new(bool)
+	resp, err := handler(context.WithValue(handlerCtx, replayedKey{}, replayed), req)
+	if err != nil || *replayed {
+		return resp, err
+	}
+
+	var id string
+	if r, ok := req.(interface{ GetId() string }); ok {
+		id = r.GetId()
+	}
+	after, _ := resp.(*user.UserResponse)
+	if id == "" {
+		id = after.GetId()
+	}
+	var before *user.UserResponse
+	if u := previous(); u != nil {
+		before = toUserResponse(u)
+	}
+	s.recordAudit(ctx, path.Base(info.FullMethod), actor(ctx), requestID(ctx), id, before, after)
+	return resp, nil
+}
+
+// markReplayed tells AuditInterceptor that the request was answered with the
+// stored response of an earlier one.
+func markReplayed(ctx context.Context) {
+	if replayed, ok := ctx.Value(replayedKey{}).(*bool); ok {
+		*replayed = true
+	}
+}
+
+// ListAuditEvents returns the audit log of a user within the requested time
+// range, newest first.
+func (s *UserServiceServer) ListAuditEvents(ctx context.Context, req *user.ListAuditEventsRequest) (*user.ListAuditEventsResponse, error) {
+	if s.audit == nil {
+		return nil, apierror.New(codes.Unimplemented, apierror.ReasonNotEnabled, nil, "Audit log is not enabled")
+	}
+
+	q := repository.AuditQuery{UserID: req.UserId, PageSize: int(req.PageSize), PageToken: req.PageToken}
+	if q.PageSize == 0 {
+		q.PageSize = defaultPageSize
+	}
+	if req.StartTime != nil {
+		q.From = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		q.To = req.EndTime.AsTime()
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return nil, apierror.InvalidArgument("Invalid request: end_time is before start_time",
+			apierror.FieldViolation("end_time", "value must not be before start_time"))
+	}
+
+	events, nextPageToken, err := s.audit.List(ctx, q)
+	if err != nil {
+		return nil, apierror.FromRepository("list audit events", err, req.UserId, 0)
+	}
+
+	resp := &user.ListAuditEventsResponse{NextPageToken: nextPageToken}
+	for _, e := range events {
+		resp.Events = append(resp.Events, toAuditEvent(e))
+	}
+	return resp, nil
+}
+
+// recordAudit appends an audit event for the change of user id from before to
+// after. The change has already been stored, so a failure is logged rather
+// than returned.
+func (s *UserServiceServer) recordAudit(ctx context.Context, rpc, actor, requestID, id string, before, after *user.UserResponse) {
+	if s.audit == nil || id == "" {
+		return
+	}
+	e := &repository.AuditEvent{
+		UserID:     id,
+		RPC:        rpc,
+		Actor:      actor,
+		RequestID:  requestID,
+		Changes:    diffUsers(before, after),
+		OccurredAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if err := s.audit.Append(ctx, e); err != nil {
+		log.Printf("Failed to record audit event for %s of user %s: %v", rpc, id, err)
+	}
+}
+
+// diffUsers lists the UserResponse fields whose value differs between before
+// and after. Either side may be nil.
+func diffUsers(before, after *user.UserResponse) []repository.FieldChange {
+	b, a := before.ProtoReflect(), after.ProtoReflect()
+	fields := b.Descriptor().Fields()
+
+	var changes []repository.FieldChange
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		old, cur := fieldValue(b, fd), fieldValue(a, fd)
+		if old != cur {
+			changes = append(changes, repository.FieldChange{Field: string(fd.Name()), Before: old, After: cur})
+		}
+	}
+	return changes
+}
+
+// fieldValue renders a field in its JSON representation. Fields of a nil
+// user and unset message fields are rendered as an empty string.
+func fieldValue(m protoreflect.Message, fd protoreflect.FieldDescriptor) string {
+	if !m.IsValid() {
+		return ""
+	}
+	v := m.Get(fd)
+	if fd.Message() != nil {
+		if !m.Has(fd) {
+			return ""
+		}
+		b, err := protojson.Marshal(v.Message().Interface())
+		if err != nil {
+			return ""
+		}
+		return strings.Trim(string(b), `"`)
+	}
+	return v.String()
+}
+
+// actor returns the caller identity sent with the request, or
+// anonymousActor. It is the actor recorded in the audit log and the default
+// of blocked_by and unblocked_by.
+func actor(ctx context.Context) string {
+	if a := firstMetadata(ctx, actorKey); a != "" {
+		return a
+	}
+	return anonymousActor
+}
+
+// requestID returns the request id sent with the request, generating one if
+// the caller did not.
+func requestID(ctx context.Context) string {
+	if id := firstMetadata(ctx, requestIDKey); id != "" {
+		return id
+	}
+	return uuid.New().String()
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func toAuditEvent(e *repository.AuditEvent) *user.AuditEvent {
+	out := &user.AuditEvent{
+		UserId:     e.UserID,
+		Rpc:        e.RPC,
+		Actor:      e.Actor,
+		RequestId:  e.RequestID,
+		OccurredAt: optionalTimestamp(e.OccurredAt),
+	}
+	for _, c := range e.Changes {
+		out.Changes = append(out.Changes, &user.FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return out
+}
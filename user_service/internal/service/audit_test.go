@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+// auditedServer is a UserServiceServer recording to an in-memory audit log,
+// whose RPCs are called through AuditInterceptor as the gRPC server would.
+type auditedServer struct {
+	*UserServiceServer
+}
+
+func newAuditedServer(opts ...Option) auditedServer {
+	opts = append(opts, WithAudit(memory.NewAuditStore()))
+	return auditedServer{NewUserServiceServer(memory.NewUserRepository(), opts...)}
+}
+
+func (s auditedServer) call(t *testing.T, ctx context.Context, method string, req interface{}, rpc func(ctx context.Context) (interface{}, error)) interface{} {
+	t.Helper()
+	resp, err := s.AuditInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return rpc(ctx) })
+	if err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+	return resp
+}
+
+// events returns the audit events of user id, oldest first, as
+// "<rpc> by <actor>: <field>=<before>-><after> ...".
+func (s auditedServer) events(t *testing.T, id string) []string {
+	t.Helper()
+	resp, err := s.ListAuditEvents(context.Background(), &user.ListAuditEventsRequest{UserId: id})
+	if err != nil {
+		t.Fatalf("ListAuditEvents: %v", err)
+	}
+	var out []string
+	for i := len(resp.GetEvents()) - 1; i >= 0; i-- {
+		e := resp.GetEvents()[i]
+		line := e.GetRpc() + " by " + e.GetActor() + ":"
+		for _, c := range e.GetChanges() {
+			if c.GetField() == "first_name" || c.GetField() == "last_name" || c.GetField() == "is_blocked" || c.GetField() == "blocked_by" {
+				line += fmt.Sprintf(" %s=%s->%s", c.GetField(), c.GetBefore(), c.GetAfter())
+			}
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func TestAuditInterceptor(t *testing.T) {
+	s := newAuditedServer()
+	admin := withMetadata(actorKey, "admin@example.com")
+	created := s.call(t, admin, user.UserService_CreateUser_FullMethodName, createRequest(), func(ctx context.Context) (interface{}, error) {
+		return s.CreateUser(ctx, createRequest())
+	}).(*user.UserResponse)
+	id := created.GetId()
+
+	rename := &user.UpdateUserRequest{Id: id, FirstName: "Zoe", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name"}}}
+	s.call(t, admin, user.UserService_UpdateUser_FullMethodName, rename, func(ctx context.Context) (interface{}, error) {
+		// Another writer changes the user after the request arrived but
+		// before its write: the change must be diffed against that version.
+		concurrent := &user.UpdateUserRequest{Id: id, FirstName: "Ann", LastName: "Roe", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name", "last_name"}}}
+		if _, err := s.UpdateUser(context.Background(), concurrent); err != nil {
+			return nil, err
+		}
+		return s.UpdateUser(ctx, rename)
+	})
+
+	block := &user.BlockUserRequest{Id: id, Reason: "spam"}
+	s.call(t, context.Background(), user.UserService_BlockUser_FullMethodName, block, func(ctx context.Context) (interface{}, error) {
+		return s.BlockUser(ctx, block)
+	})
+
+	del := &user.DeleteUserRequest{Id: id}
+	if resp := s.call(t, admin, user.UserService_DeleteUser_FullMethodName, del, func(ctx context.Context) (interface{}, error) {
+		return s.DeleteUser(ctx, del)
+	}); resp.(*emptypb.Empty) == nil {
+		t.Fatal("DeleteUser returned no response")
+	}
+
+	want := []string{
+		"CreateUser by admin@example.com: first_name=->Jane last_name=->Doe is_blocked=->false",
+		"UpdateUser by admin@example.com: first_name=Ann->Zoe",
+		// Without an actor, blocked_by and the audit log agree on it.
+		"BlockUser by anonymous: is_blocked=false->true blocked_by=->anonymous",
+		"DeleteUser by admin@example.com: first_name=Zoe-> last_name=Roe-> is_blocked=true-> blocked_by=anonymous->",
+	}
+	if got := s.events(t, id); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("audit log =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestAuditSkipsReplays(t *testing.T) {
+	s := newAuditedServer(WithIdempotency(memory.NewIdempotencyStore(), time.Hour))
+	ctx := withMetadata(idempotencyKeyKey, "key-1", actorKey, "admin@example.com")
+	var id string
+	for i := 0; i < 3; i++ {
+		resp := s.call(t, ctx, user.UserService_CreateUser_FullMethodName, createRequest(), func(ctx context.Context) (interface{}, error) {
+			return s.CreateUser(ctx, createRequest())
+		})
+		id = resp.(*user.UserResponse).GetId()
+	}
+	if got := s.events(t, id); len(got) != 1 {
+		t.Errorf("audit log = %q, want only the first CreateUser", got)
+	}
+}
+
+func TestListAuditEventsTimeRange(t *testing.T) {
+	s := newAuditedServer()
+	now := time.Now()
+	_, err := s.ListAuditEvents(context.Background(), &user.ListAuditEventsRequest{
+		UserId:    "5b7f0c4e-3f8a-4d6e-9f61-1d2c3b4a5e6f",
+		StartTime: timestamppb.New(now),
+		EndTime:   timestamppb.New(now.Add(-time.Minute)),
+	})
+	assertStatus(t, err, codes.InvalidArgument, apierror.ReasonValidationFailed)
+
+	_, err = NewUserServiceServer(memory.NewUserRepository()).ListAuditEvents(context.Background(), &user.ListAuditEventsRequest{})
+	assertStatus(t, err, codes.Unimplemented, apierror.ReasonNotEnabled)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"log"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/repository"
+	"user_service/protogen/user"
+)
+
+// blockSweeperActor is recorded as the actor of blocks lifted because they
+// expired.
+const blockSweeperActor = "system:block-sweeper"
+
+// WithBlockHistory records every block and unblock in store and enables
+// ListBlockHistory.
+func WithBlockHistory(store repository.BlockEventStore) Option {
+	return func(s *UserServiceServer) {
+		s.blockEvents = store
+	}
+}
+
+// ListBlockHistory returns the block and unblock events of a user, newest
+// first.
+func (s *UserServiceServer) ListBlockHistory(ctx context.Context, req *user.ListBlockHistoryRequest) (*user.ListBlockHistoryResponse, error) {
+	if s.blockEvents == nil {
+		return nil, apierror.New(codes.Unimplemented, apierror.ReasonNotEnabled, nil, "Block history is not enabled")
+	}
+	if _, err := s.repo.Get(ctx, req.Id); err != nil {
+		return nil, apierror.FromRepository("fetch user", err, req.Id, 0)
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	events, nextPageToken, err := s.blockEvents.List(ctx, req.Id, pageSize, req.PageToken)
+	if err != nil {
+		return nil, apierror.FromRepository("list block history", err, req.Id, 0)
+	}
+
+	resp := &user.ListBlockHistoryResponse{NextPageToken: nextPageToken}
+	for _, e := range events {
+		resp.Events = append(resp.Events, toBlockEvent(e))
+	}
+	return resp, nil
+}
+
+// recordBlockEvent appends a block history event for u. The block itself has
+// already been stored, so a failure is logged rather than returned.
+func (s *UserServiceServer) recordBlockEvent(ctx context.Context, u *repository.User, action repository.BlockAction, reason, actor string) {
+	if s.blockEvents == nil {
+		return
+	}
+	e := &repository.BlockEvent{
+		UserID:     u.ID,
+		Action:     action,
+		Reason:     reason,
+		Actor:      actor,
+		OccurredAt: u.UpdatedAt,
+	}
+	if action == repository.BlockActionBlocked {
+		e.ExpiresAt = u.BlockExpiresAt
+	}
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now().UTC().Truncate(time.Millisecond)
+	}
+	if err := s.blockEvents.Append(ctx, e); err != nil {
+		log.Printf("Failed to record %s event of user %s: %v", action, u.ID, err)
+	}
+}
+
+func toBlockEvent(e *repository.BlockEvent) *user.BlockEvent {
+	action := user.BlockEvent_ACTION_UNSPECIFIED
+	switch e.Action {
+	case repository.BlockActionBlocked:
+		action = user.BlockEvent_BLOCKED
+	case repository.BlockActionUnblocked:
+		action = user.BlockEvent_UNBLOCKED
+	}
+	return &user.BlockEvent{
+		Action:     action,
+		Reason:     e.Reason,
+		Actor:      e.Actor,
+		ExpiresAt:  optionalTimestamp(e.ExpiresAt),
+		OccurredAt: optionalTimestamp(e.OccurredAt),
+	}
+}
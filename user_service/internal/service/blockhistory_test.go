@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+func TestListBlockHistory(t *testing.T) {
+	ctx := context.Background()
+	s := NewUserServiceServer(memory.NewUserRepository(), WithBlockHistory(memory.NewBlockEventStore()))
+	created := mustCreate(t, s)
+	admin := withMetadata(actorKey, "admin@example.com")
+
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+	steps := []func() error{
+		func() error {
+			_, err := s.BlockUser(admin, &user.BlockUserRequest{Id: created.GetId(), Reason: "spam"})
+			return err
+		},
+		func() error {
+			_, err := s.UnblockUser(admin, &user.UnblockUserRequest{Id: created.GetId(), Reason: "appeal granted"})
+			return err
+		},
+		func() error {
+			_, err := s.BlockUser(admin, &user.BlockUserRequest{Id: created.GetId(), Reason: "fraud", BlockedBy: "fraud-team", ExpiresAt: timestamppb.New(expiresAt)})
+			return err
+		},
+		func() error {
+			time.Sleep(time.Until(expiresAt) + 10*time.Millisecond)
+			_, err := s.SweepExpiredBlocks(ctx)
+			return err
+		},
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+
+	want := []struct {
+		action user.BlockEvent_Action
+		reason string
+		actor  string
+	}{
+		{user.BlockEvent_UNBLOCKED, "block expired", blockSweeperActor},
+		{user.BlockEvent_BLOCKED, "fraud", "fraud-team"},
+		{user.BlockEvent_UNBLOCKED, "appeal granted", "admin@example.com"},
+		{user.BlockEvent_BLOCKED, "spam", "admin@example.com"},
+	}
+	var got []*user.BlockEvent
+	req := &user.ListBlockHistoryRequest{Id: created.GetId(), PageSize: 3}
+	for page := 0; ; page++ {
+		resp, err := s.ListBlockHistory(ctx, req)
+		if err != nil {
+			t.Fatalf("ListBlockHistory page %d: %v", page, err)
+		}
+		got = append(got, resp.GetEvents()...)
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	if len(got) != len(want) {
+		t.Fatalf("block history has %d events, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if e := got[i]; e.GetAction() != w.action || e.GetReason() != w.reason || e.GetActor() != w.actor {
+			t.Errorf("event %d = %s %q by %q, want %s %q by %q", i, e.GetAction(), e.GetReason(), e.GetActor(), w.action, w.reason, w.actor)
+		}
+	}
+	if e := got[1]; !e.GetExpiresAt().AsTime().Equal(expiresAt.UTC().Truncate(time.Millisecond)) {
+		t.Errorf("timed block event expires at %s, want %s", e.GetExpiresAt().AsTime(), expiresAt)
+	}
+
+	_, err := s.ListBlockHistory(ctx, &user.ListBlockHistoryRequest{Id: "5b7f0c4e-3f8a-4d6e-9f61-1d2c3b4a5e6f"})
+	assertStatus(t, err, codes.NotFound, apierror.ReasonUserNotFound)
+	_, err = NewUserServiceServer(memory.NewUserRepository()).ListBlockHistory(ctx, &user.ListBlockHistoryRequest{Id: created.GetId()})
+	assertStatus(t, err, codes.Unimplemented, apierror.ReasonNotEnabled)
+}
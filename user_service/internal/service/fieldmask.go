@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"user_service/internal/apierror"
+	"user_service/internal/interceptor"
+	"user_service/internal/repository"
+	"user_service/protogen/user"
+)
+
+// UpdatableUserFields lists the update_mask paths accepted by UpdateUser. An
+// empty mask selects all of them.
+var UpdatableUserFields = []string{"first_name", "last_name", "gender", "date_of_birth"}
+
+// profileUpdate turns the fields selected by the request's update_mask into a
+// repository.ProfileUpdate. The selected fields must be set and pass their
+// field rules, while the remaining fields are ignored, whatever their value.
+// Unknown paths are rejected. Errors are InvalidArgument status errors naming
+// the offending field. The validation interceptor leaves UpdateUserRequest to
+// this function, as it cannot tell which fields the mask selects.
+func profileUpdate(req *user.UpdateUserRequest) (repository.ProfileUpdate, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = UpdatableUserFields
+	}
+
+	var upd repository.ProfileUpdate
+	selected := &user.UpdateUserRequest{Id: req.Id, ExpectedVersion: req.ExpectedVersion}
+	for _, path := range paths {
+		var value string
+		switch path {
+		case "first_name":
+			value, upd.FirstName, selected.FirstName = req.FirstName, &req.FirstName, req.FirstName
+		case "last_name":
+			value, upd.LastName, selected.LastName = req.LastName, &req.LastName, req.LastName
+		case "gender":
+			value, upd.Gender, selected.Gender = req.Gender, &req.Gender, req.Gender
+		case "date_of_birth":
+			value, upd.DateOfBirth, selected.DateOfBirth = req.DateOfBirth, &req.DateOfBirth, req.DateOfBirth
+		default:
+			return repository.ProfileUpdate{}, apierror.InvalidArgument(fmt.Sprintf("Invalid request: unknown update_mask path %q", path),
+				apierror.FieldViolation("update_mask", fmt.Sprintf("unknown path %q", path)))
+		}
+		if value == "" {
+			return repository.ProfileUpdate{}, apierror.InvalidArgument(fmt.Sprintf("Invalid request: %s is required when included in update_mask", path),
+				apierror.FieldViolation(path, "value is required when included in update_mask"))
+		}
+	}
+	if err := interceptor.ValidateMessage(selected); err != nil {
+		return repository.ProfileUpdate{}, err
+	}
+
+	return upd, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"testing"
+	"user_service/internal/apierror"
+	"user_service/internal/interceptor"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+func TestUpdateUserFieldMask(t *testing.T) {
+	mask := func(paths ...string) *fieldmaskpb.FieldMask { return &fieldmaskpb.FieldMask{Paths: paths} }
+	tests := []struct {
+		name       string
+		req        *user.UpdateUserRequest
+		wantField  string
+		wantFirst  string
+		wantLast   string
+		wantGender string
+	}{
+		{
+			name:       "masked field only",
+			req:        &user.UpdateUserRequest{FirstName: "Zoe", LastName: "Ignored", UpdateMask: mask("first_name")},
+			wantFirst:  "Zoe",
+			wantLast:   "Doe",
+			wantGender: "Female",
+		},
+		{
+			name:       "invalid field outside the mask",
+			req:        &user.UpdateUserRequest{FirstName: "Zoe", Gender: "bogus", UpdateMask: mask("first_name")},
+			wantFirst:  "Zoe",
+			wantLast:   "Doe",
+			wantGender: "Female",
+		},
+		{
+			name:       "empty mask replaces every field",
+			req:        &user.UpdateUserRequest{FirstName: "Zoe", LastName: "Roe", Gender: "Other", DateOfBirth: "1991-02-03"},
+			wantFirst:  "Zoe",
+			wantLast:   "Roe",
+			wantGender: "Other",
+		},
+		{
+			name:      "invalid masked field",
+			req:       &user.UpdateUserRequest{FirstName: "Zoe", Gender: "bogus", UpdateMask: mask("first_name", "gender")},
+			wantField: "gender",
+		},
+		{
+			name:      "empty mask with missing field",
+			req:       &user.UpdateUserRequest{FirstName: "Zoe"},
+			wantField: "last_name",
+		},
+		{
+			name:      "masked field unset",
+			req:       &user.UpdateUserRequest{UpdateMask: mask("gender")},
+			wantField: "gender",
+		},
+		{
+			name:      "unknown path",
+			req:       &user.UpdateUserRequest{UpdateMask: mask("email")},
+			wantField: "update_mask",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewUserServiceServer(memory.NewUserRepository())
+			created := mustCreate(t, s)
+			tt.req.Id = created.GetId()
+
+			// Run through the validation interceptor, as the gRPC server does.
+			resp, err := interceptor.Validate(context.Background(), tt.req, &grpc.UnaryServerInfo{FullMethod: user.UserService_UpdateUser_FullMethodName},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					return s.UpdateUser(ctx, req.(*user.UpdateUserRequest))
+				})
+			if tt.wantField != "" {
+				assertStatus(t, err, codes.InvalidArgument, apierror.ReasonValidationFailed)
+				if got := violatedField(err); got != tt.wantField {
+					t.Errorf("violated field = %q, want %q", got, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateUser: %v", err)
+			}
+			got := resp.(*user.UserResponse)
+			if got.GetFirstName() != tt.wantFirst || got.GetLastName() != tt.wantLast || got.GetGender() != tt.wantGender {
+				t.Errorf("user = %s %s (%s), want %s %s (%s)", got.GetFirstName(), got.GetLastName(), got.GetGender(),
+					tt.wantFirst, tt.wantLast, tt.wantGender)
+			}
+			if got.GetVersion() != created.GetVersion()+1 {
+				t.Errorf("version = %d, want %d", got.GetVersion(), created.GetVersion()+1)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"log"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/repository"
+	"user_service/protogen/user"
+)
+
+// idempotencyKeyKey is the metadata key carrying the client's idempotency key;
+// the REST gateway forwards the Idempotency-Key header under it.
+const idempotencyKeyKey = "idempotency-key"
+
+// defaultIdempotencyTTL is used when WithIdempotency is given no TTL.
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// idempotencyLease is how long a key stays claimed by a request that has not
+// finished. It bounds how long retries are aborted after a crash.
+const idempotencyLease = time.Minute
+
+// WithIdempotency enables Idempotency-Key handling for CreateUser, keeping
+// responses in store for ttl so that retries can be replayed.
+func WithIdempotency(store repository.IdempotencyStore, ttl time.Duration) Option {
+	return func(s *UserServiceServer) {
+		if ttl <= 0 {
+			ttl = defaultIdempotencyTTL
+		}
+		s.idempotency = store
+		s.idempotencyTTL = ttl
+	}
+}
+
+// createUserOnce runs createUser at most once per idempotency key. A retry
+// with the same request gets the stored response, a retry with a different
+// request is rejected, and a retry while the first request is still running
+// is aborted.
+func (s *UserServiceServer) createUserOnce(ctx context.Context, key string, req *user.CreateUserRequest) (*user.UserResponse, error) {
+	fp, err := fingerprint(req)
+	if err != nil {
+		return nil, apierror.Internal("create user", err)
+	}
+
+	storeKey := "CreateUser/" + key
+	rec, err := s.idempotency.Begin(ctx, storeKey, fp, min(idempotencyLease, s.idempotencyTTL))
+	if err != nil {
+		return nil, apierror.Internal("claim idempotency key", err)
+	}
+	if rec != nil {
+		if rec.Fingerprint != fp {
+			return nil, apierror.New(codes.InvalidArgument, apierror.ReasonIdempotencyKeyReused, map[string]string{"idempotency_key": key},
+				fmt.Sprintf("Idempotency-Key %q was already used with a different request", key))
+		}
+		if rec.Response == nil {
+			return nil, apierror.New(codes.Aborted, apierror.ReasonRequestInProgress, map[string]string{"idempotency_key": key},
+				fmt.Sprintf("A request with Idempotency-Key %q is still in progress", key))
+		}
+		var resp user.UserResponse
+		if err := proto.Unmarshal(rec.Response, &resp); err != nil {
+			return nil, apierror.Internal("replay response", err)
+		}
+		markReplayed(ctx)
+		return &resp, nil
+	}
+
+	// The claim is released or completed even if the caller went away.
+	storeCtx := context.WithoutCancel(ctx)
+	resp, err := s.createUser(ctx, req)
+	if err != nil {
+		if err := s.idempotency.Abandon(storeCtx, storeKey); err != nil {
+			log.Printf("Failed to release idempotency key: %v", err)
+		}
+		return nil, err
+	}
+
+	b, err := proto.Marshal(resp)
+	if err == nil {
+		err = s.idempotency.Complete(storeCtx, storeKey, fp, b, s.idempotencyTTL)
+	}
+	if err != nil {
+		log.Printf("Failed to store idempotent response: %v", err)
+	}
+	return resp, nil
+}
+
+// idempotencyKey returns the idempotency key sent with the request, if any.
+func idempotencyKey(ctx context.Context) string {
+	return firstMetadata(ctx, idempotencyKeyKey)
+}
+
+// fingerprint hashes the deterministic wire encoding of a request.
+func fingerprint(m proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"testing"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/repository/memory"
+)
+
+func TestCreateUserIdempotency(t *testing.T) {
+	store := memory.NewIdempotencyStore()
+	s := NewUserServiceServer(memory.NewUserRepository(), WithIdempotency(store, time.Hour))
+	ctx := withMetadata(idempotencyKeyKey, "key-1")
+
+	first, err := s.CreateUser(ctx, createRequest())
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	replay, err := s.CreateUser(ctx, createRequest())
+	if err != nil {
+		t.Fatalf("replayed CreateUser: %v", err)
+	}
+	if replay.GetId() != first.GetId() {
+		t.Errorf("replay created user %s, want the stored response for %s", replay.GetId(), first.GetId())
+	}
+
+	other := createRequest()
+	other.Email = "other@example.com"
+	other.PhoneNumber = "+4915100000002"
+	_, err = s.CreateUser(ctx, other)
+	assertStatus(t, err, codes.InvalidArgument, apierror.ReasonIdempotencyKeyReused)
+
+	// A claim that is still held aborts concurrent retries.
+	fp, err := fingerprint(other)
+	if err != nil {
+		t.Fatalf("fingerprint: %v", err)
+	}
+	if _, err := store.Begin(context.Background(), "CreateUser/key-2", fp, time.Minute); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	_, err = s.CreateUser(withMetadata(idempotencyKeyKey, "key-2"), other)
+	assertStatus(t, err, codes.Aborted, apierror.ReasonRequestInProgress)
+
+	// A failed request releases its key, so the retry runs again.
+	ctx = withMetadata(idempotencyKeyKey, "key-3")
+	for i := 0; i < 2; i++ {
+		_, err = s.CreateUser(ctx, createRequest())
+		assertStatus(t, err, codes.AlreadyExists, apierror.ReasonDuplicateEmail)
+	}
+	if _, err := s.CreateUser(ctx, other); err != nil {
+		t.Errorf("CreateUser with the key of a failed request: %v", err)
+	}
+}
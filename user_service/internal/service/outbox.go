@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log"
+	"time"
+	"user_service/internal/events"
+	"user_service/internal/repository"
+	"user_service/protogen/user"
+)
+
+const (
+	// defaultRelayInterval is used by RunOutboxRelay when given no interval.
+	defaultRelayInterval = time.Second
+
+	// defaultRelayBatchSize is used by RunOutboxRelay when given no batch size.
+	defaultRelayBatchSize = 100
+)
+
+// WithEventPublisher enables RunOutboxRelay, which publishes the events
+// recorded in outbox through publisher.
+func WithEventPublisher(outbox repository.Outbox, publisher events.EventPublisher) Option {
+	return func(s *UserServiceServer) {
+		s.outbox = outbox
+		s.publisher = publisher
+	}
+}
+
+// RunOutboxRelay publishes pending outbox events every interval until ctx is
+// cancelled. Full batches are followed by the next batch right away.
+func (s *UserServiceServer) RunOutboxRelay(ctx context.Context, interval time.Duration, batchSize int) {
+	if s.outbox == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultRelayBatchSize
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.RelayEvents(ctx, batchSize)
+		if err != nil {
+			log.Printf("Failed to relay outbox events: %v", err)
+		}
+		if err == nil && n == batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayEvents publishes up to batchSize pending events and removes them from
+// the outbox, returning how many were published. Publishing stops at the first
+// failure so that the events of a user are never published out of order; the
+// failed event and those after it are retried by the next call. An event
+// published but not removed is published again, so delivery is at least once.
+func (s *UserServiceServer) RelayEvents(ctx context.Context, batchSize int) (int, error) {
+	pending, err := s.outbox.PendingEvents(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		published []*repository.OutboxEvent
+		pubErr    error
+	)
+	for _, e := range pending {
+		if pubErr = s.publisher.Publish(ctx, toUserEvent(e)); pubErr != nil {
+			break
+		}
+		published = append(published, e)
+	}
+	if len(published) > 0 {
+		if err := s.outbox.RemoveEvents(ctx, published); err != nil {
+			return len(published), err
+		}
+	}
+	return len(published), pubErr
+}
+
+func toUserEvent(e *repository.OutboxEvent) *user.UserEvent {
+	u := toUserResponse(&e.User)
+	out := &user.UserEvent{
+		EventId:    e.ID,
+		UserId:     e.User.ID,
+		Version:    e.User.Version,
+		OccurredAt: timestamppb.New(e.OccurredAt),
+	}
+	switch e.Type {
+	case repository.EventUserCreated:
+		out.Event = &user.UserEvent_UserCreated{UserCreated: &user.UserCreated{User: u}}
+	case repository.EventUserBlocked:
+		out.Event = &user.UserEvent_UserBlocked{UserBlocked: &user.UserBlocked{User: u}}
+	case repository.EventUserUnblocked:
+		out.Event = &user.UserEvent_UserUnblocked{UserUnblocked: &user.UserUnblocked{User: u}}
+	case repository.EventContactChanged:
+		out.Event = &user.UserEvent_ContactChanged{ContactChanged: &user.ContactChanged{
+			User:     u,
+			Channel:  protoContactChannel(e.Channel),
+			Previous: e.PreviousContact,
+		}}
+	default:
+		out.Event = &user.UserEvent_UserUpdated{UserUpdated: &user.UserUpdated{User: u}}
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"sync"
+	"testing"
+	"time"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+// testPublisher keeps the published events and fails the publication number
+// failAt, counting from 1, if it is set.
+type testPublisher struct {
+	mu        sync.Mutex
+	failAt    int
+	calls     int
+	published []*user.UserEvent
+}
+
+func (p *testPublisher) Publish(ctx context.Context, e *user.UserEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.calls == p.failAt {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, e)
+	return nil
+}
+
+func (p *testPublisher) versions() []int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var versions []int64
+	for _, e := range p.published {
+		versions = append(versions, e.GetVersion())
+	}
+	return versions
+}
+
+// recordEvents creates a user and renames it twice, recording three events.
+func recordEvents(t *testing.T, s *UserServiceServer) *user.UserResponse {
+	t.Helper()
+	created := mustCreate(t, s)
+	for _, name := range []string{"Zoe", "Ann"} {
+		if _, err := s.UpdateUser(context.Background(), &user.UpdateUserRequest{Id: created.GetId(), FirstName: name,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name"}}}); err != nil {
+			t.Fatalf("UpdateUser: %v", err)
+		}
+	}
+	return created
+}
+
+func TestRelayEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewUserRepository()
+	publisher := &testPublisher{failAt: 2}
+	s := NewUserServiceServer(repo, WithEventPublisher(repo, publisher))
+	created := recordEvents(t, s)
+
+	// Publishing stops at the first failure, and the failed event is retried.
+	if n, err := s.RelayEvents(ctx, 10); n != 1 || err == nil {
+		t.Fatalf("RelayEvents = %d, %v; want 1 and the publish error", n, err)
+	}
+	if n, err := s.RelayEvents(ctx, 10); n != 2 || err != nil {
+		t.Fatalf("RelayEvents = %d, %v; want 2", n, err)
+	}
+	if n, err := s.RelayEvents(ctx, 10); n != 0 || err != nil {
+		t.Fatalf("RelayEvents with an empty outbox = %d, %v; want 0", n, err)
+	}
+
+	if got := publisher.versions(); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("published versions %v, want [1 2 3]", got)
+	}
+	first, last := publisher.published[0], publisher.published[2]
+	if first.GetUserCreated().GetUser().GetId() != created.GetId() || first.GetUserId() != created.GetId() {
+		t.Errorf("first event = %v, want UserCreated of %s", first, created.GetId())
+	}
+	if last.GetUserUpdated().GetUser().GetFirstName() != "Ann" || last.GetEventId() == "" {
+		t.Errorf("last event = %v, want UserUpdated with first name Ann", last)
+	}
+}
+
+func TestRunOutboxRelay(t *testing.T) {
+	repo := memory.NewUserRepository()
+	publisher := &testPublisher{}
+	s := NewUserServiceServer(repo, WithEventPublisher(repo, publisher))
+	recordEvents(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// A batch size of 2 makes the relay drain the outbox in two passes.
+		s.RunOutboxRelay(ctx, time.Millisecond, 2)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for len(publisher.versions()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if got := publisher.versions(); len(got) != 3 {
+		t.Fatalf("published versions %v, want 3 events", got)
+	}
+	if pending, err := repo.PendingEvents(context.Background(), 10); err != nil || len(pending) != 0 {
+		t.Errorf("pending events after relaying = %d, %v; want none", len(pending), err)
+	}
+}
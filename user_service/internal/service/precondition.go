@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"strings"
+	"user_service/internal/apierror"
+)
+
+// ifMatchKey is the metadata key the REST gateway forwards the If-Match
+// header under.
+const ifMatchKey = "if-match"
+
+// expectedVersion returns the version the caller expects the user to be at
+// before a mutation. An explicit expected_version in the request wins;
+// otherwise the If-Match header forwarded by the gateway is used. Zero means
+// the mutation is unconditional. A malformed If-Match header is reported as an
+// InvalidArgument status error.
+func expectedVersion(ctx context.Context, fromRequest int64) (int64, error) {
+	if fromRequest != 0 {
+		return fromRequest, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(ifMatchKey)
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	etag := strings.TrimSpace(values[0])
+	if etag == "*" {
+		return 0, nil
+	}
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(etag, "W/"))
+	if err != nil {
+		return 0, malformedIfMatch(etag)
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version < 1 {
+		return 0, malformedIfMatch(etag)
+	}
+	return version, nil
+}
+
+func malformedIfMatch(etag string) error {
+	return apierror.InvalidArgument(fmt.Sprintf("Invalid request: malformed If-Match header %q", etag),
+		apierror.FieldViolation("expected_version", "If-Match must be a quoted version number or *"))
+}
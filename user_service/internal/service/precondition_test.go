@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"testing"
+	"user_service/internal/apierror"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		ifMatch         string
+		expectedVersion int64
+		wantCode        codes.Code
+		wantReason      string
+	}{
+		{"current version", `"1"`, 0, codes.OK, ""},
+		{"weak etag", `W/"1"`, 0, codes.OK, ""},
+		{"any version", `*`, 0, codes.OK, ""},
+		{"stale version", `"2"`, 0, codes.FailedPrecondition, apierror.ReasonVersionMismatch},
+		{"malformed", `1`, 0, codes.InvalidArgument, apierror.ReasonValidationFailed},
+		{"expected_version wins", `"2"`, 1, codes.OK, ""},
+		{"stale expected_version", `*`, 2, codes.FailedPrecondition, apierror.ReasonVersionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewUserRepository()
+			s := NewUserServiceServer(repo)
+			created := mustCreate(t, s)
+
+			ctx := withMetadata(ifMatchKey, tt.ifMatch)
+			_, err := s.BlockUser(ctx, &user.BlockUserRequest{Id: created.GetId(), Reason: "spam", ExpectedVersion: tt.expectedVersion})
+			if tt.wantCode == codes.OK {
+				if err != nil {
+					t.Fatalf("BlockUser: %v", err)
+				}
+				return
+			}
+			assertStatus(t, err, tt.wantCode, tt.wantReason)
+			if u, _ := repo.Get(context.Background(), created.GetId()); u.IsBlocked || u.Version != created.GetVersion() {
+				t.Errorf("user = blocked %v at version %d, want unchanged after the failed precondition", u.IsBlocked, u.Version)
+			}
+		})
+	}
+}
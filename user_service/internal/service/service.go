@@ -2,40 +2,69 @@ package service
 
 import (
 	"context"
-	"github.com/envoyproxy/protoc-gen-validate/validate"
-	"github.com/gocql/gocql"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"log"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/events"
+	"user_service/internal/notify"
+	"user_service/internal/repository"
 	"user_service/protogen/user"
 )
 
+// defaultPageSize is used by ListUsers when the request does not set page_size.
+const defaultPageSize = 50
+
 type UserServiceServer struct {
 	user.UnimplementedUserServiceServer
-	session *gocql.Session
-}
+	repo repository.UserRepository
+
+	idempotency    repository.IdempotencyStore
+	idempotencyTTL time.Duration
+
+	blockEvents repository.BlockEventStore
+	audit       repository.AuditStore
 
-func NewUserServiceServer(session *gocql.Session) *UserServiceServer {
-	return &UserServiceServer{session: session}
+	verifications   repository.VerificationStore
+	notifier        notify.Notifier
+	codeTTL         time.Duration
+	maxCodeAttempts int
+	resendCooldown  time.Duration
+
+	outbox    repository.Outbox
+	publisher events.EventPublisher
+	feed      *events.Broker
+
+	webhooks             repository.WebhookStore
+	allowPrivateWebhooks bool
 }
 
-// CreateUser creates a new user in the database.
-func (s *UserServiceServer) CreateUser(ctx context.Context, req *user.CreateUserRequest) (*user.UserResponse, error) {
-	// Validate the request
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %v", err)
+// Option configures optional collaborators of a UserServiceServer.
+type Option func(*UserServiceServer)
+
+func NewUserServiceServer(repo repository.UserRepository, opts ...Option) *UserServiceServer {
+	s := &UserServiceServer{repo: repo}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
+}
 
-	id := uuid.New().String()
-	query := `INSERT INTO users (id, first_name, last_name, gender, date_of_birth, phone_number, email, is_blocked) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
-	if err := s.session.Query(query, id, req.FirstName, req.LastName, req.Gender, req.DateOfBirth, req.PhoneNumber, req.Email, false).Exec(); err != nil {
-		log.Printf("Failed to create user: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to create user: %v", err)
+// CreateUser creates a new user in the database. Requests carrying an
+// Idempotency-Key are only executed once; retries get the stored response.
+func (s *UserServiceServer) CreateUser(ctx context.Context, req *user.CreateUserRequest) (*user.UserResponse, error) {
+	if key := idempotencyKey(ctx); key != "" && s.idempotency != nil {
+		return s.createUserOnce(ctx, key, req)
 	}
+	return s.createUser(ctx, req)
+}
 
-	return &user.UserResponse{
-		Id:          id,
+func (s *UserServiceServer) createUser(ctx context.Context, req *user.CreateUserRequest) (*user.UserResponse, error) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	u := &repository.User{
+		ID:          uuid.New().String(),
 		FirstName:   req.FirstName,
 		LastName:    req.LastName,
 		Gender:      req.Gender,
@@ -43,185 +72,216 @@ func (s *UserServiceServer) CreateUser(ctx context.Context, req *user.CreateUser
 		PhoneNumber: req.PhoneNumber,
 		Email:       req.Email,
 		IsBlocked:   false,
-	}, nil
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Version:     1,
+	}
+	if err := s.repo.Create(ctx, u); err != nil {
+		return nil, apierror.FromRepository("create user", err, "", 0)
+	}
+
+	return toUserResponse(u), nil
 }
 
-// UpdateUser updates an existing user's details.
+// UpdateUser updates the profile fields of an existing user selected by the
+// request's update_mask.
 func (s *UserServiceServer) UpdateUser(ctx context.Context, req *user.UpdateUserRequest) (*user.UserResponse, error) {
-	// Validate the request
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %v", err)
+	upd, err := profileUpdate(req)
+	if err != nil {
+		return nil, err
 	}
-
-	query := `UPDATE users SET first_name = ?, last_name = ?, gender = ?, date_of_birth = ? WHERE id = ?`
-	if err := s.session.Query(query, req.FirstName, req.LastName, req.Gender, req.DateOfBirth, req.Id).Exec(); err != nil {
-		log.Printf("Failed to update user: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to update user: %v", err)
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	// Fetch the updated user details
-	var (
-		firstName, lastName, gender, dateOfBirth, phoneNumber, email string
-		isBlocked                                                    bool
-	)
-	if err := s.session.Query(`SELECT first_name, last_name, gender, date_of_birth, phone_number, email, is_blocked FROM users WHERE id = ?`, req.Id).Scan(
-		&firstName, &lastName, &gender, &dateOfBirth, &phoneNumber, &email, &isBlocked,
-	); err != nil {
-		log.Printf("Failed to fetch updated user: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to fetch updated user: %v", err)
+	u, err := s.repo.Update(ctx, req.Id, upd, version)
+	if err != nil {
+		return nil, apierror.FromRepository("update user", err, req.Id, version)
 	}
 
-	return &user.UserResponse{
-		Id:          req.Id,
-		FirstName:   firstName,
-		LastName:    lastName,
-		Gender:      gender,
-		DateOfBirth: dateOfBirth,
-		PhoneNumber: phoneNumber,
-		Email:       email,
-		IsBlocked:   isBlocked,
-	}, nil
+	return toUserResponse(u), nil
 }
 
-// BlockUser blocks a user by setting the is_blocked flag to true.
+// BlockUser blocks a user by setting the is_blocked flag to true and records
+// why, by whom and until when. blocked_by defaults to the caller's actor.
+// Timed blocks are lifted by RunBlockSweeper.
 func (s *UserServiceServer) BlockUser(ctx context.Context, req *user.BlockUserRequest) (*user.UserResponse, error) {
-	// Validate the request
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %v", err)
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	query := `UPDATE users SET is_blocked = true WHERE id = ?`
-	if err := s.session.Query(query, req.Id).Exec(); err != nil {
-		log.Printf("Failed to block user: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to block user: %v", err)
+	block := repository.BlockDetails{Reason: req.Reason, BlockedBy: req.BlockedBy}
+	if block.BlockedBy == "" {
+		block.BlockedBy = actor(ctx)
 	}
-
-	// Fetch the updated user details
-	var (
-		firstName, lastName, gender, dateOfBirth, phoneNumber, email string
-		isBlocked                                                    bool
-	)
-	if err := s.session.Query(`SELECT first_name, last_name, gender, date_of_birth, phone_number, email, is_blocked FROM users WHERE id = ?`, req.Id).Scan(
-		&firstName, &lastName, &gender, &dateOfBirth, &phoneNumber, &email, &isBlocked,
-	); err != nil {
-		log.Printf("Failed to fetch blocked user: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to fetch blocked user: %v", err)
+	if req.ExpiresAt != nil {
+		block.ExpiresAt = req.ExpiresAt.AsTime().UTC().Truncate(time.Millisecond)
 	}
+	u, err := s.repo.Block(ctx, req.Id, block, version)
+	if err != nil {
+		return nil, apierror.FromRepository("block user", err, req.Id, version)
+	}
+	s.recordBlockEvent(ctx, u, repository.BlockActionBlocked, block.Reason, block.BlockedBy)
 
-	return &user.UserResponse{
-		Id:          req.Id,
-		FirstName:   firstName,
-		LastName:    lastName,
-		Gender:      gender,
-		DateOfBirth: dateOfBirth,
-		PhoneNumber: phoneNumber,
-		Email:       email,
-		IsBlocked:   true,
-	}, nil
+	return toUserResponse(u), nil
 }
 
-// UnblockUser unblocks a user by setting the is_blocked flag to false.
+// UnblockUser unblocks a user by setting the is_blocked flag to false and
+// clearing the block details.
 func (s *UserServiceServer) UnblockUser(ctx context.Context, req *user.UnblockUserRequest) (*user.UserResponse, error) {
-	// Validate the request
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %v", err)
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	query := `UPDATE users SET is_blocked = false WHERE id = ?`
-	if err := s.session.Query(query, req.Id).Exec(); err != nil {
-		log.Printf("Failed to unblock user: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to unblock user: %v", err)
+	u, err := s.repo.Unblock(ctx, req.Id, version)
+	if err != nil {
+		return nil, apierror.FromRepository("unblock user", err, req.Id, version)
 	}
-
-	// Fetch the updated user details
-	var (
-		firstName, lastName, gender, dateOfBirth, phoneNumber, email string
-		isBlocked                                                    bool
-	)
-	if err := s.session.Query(`SELECT first_name, last_name, gender, date_of_birth, phone_number, email, is_blocked FROM users WHERE id = ?`, req.Id).Scan(
-		&firstName, &lastName, &gender, &dateOfBirth, &phoneNumber, &email, &isBlocked,
-	); err != nil {
-		log.Printf("Failed to fetch unblocked user: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to fetch unblocked user: %v", err)
+	unblockedBy := req.UnblockedBy
+	if unblockedBy == "" {
+		unblockedBy = actor(ctx)
 	}
+	s.recordBlockEvent(ctx, u, repository.BlockActionUnblocked, req.Reason, unblockedBy)
 
-	return &user.UserResponse{
-		Id:          req.Id,
-		FirstName:   firstName,
-		LastName:    lastName,
-		Gender:      gender,
-		DateOfBirth: dateOfBirth,
-		PhoneNumber: phoneNumber,
-		Email:       email,
-		IsBlocked:   false,
-	}, nil
+	return toUserResponse(u), nil
 }
 
-// UpdateContact updates a user's phone number and/or email.
+// UpdateContact stages a change of a user's phone number and/or email. The
+// changed contacts are kept as pending and a verification code is sent to
+// each of them; they replace the current contacts, which stay in use until
+// then, once confirmed with ConfirmContactVerification.
 func (s *UserServiceServer) UpdateContact(ctx context.Context, req *user.UpdateContactRequest) (*user.UserResponse, error) {
-	// Validate the request
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %v", err)
+	if s.verifications == nil {
+		return nil, apierror.New(codes.Unimplemented, apierror.ReasonNotEnabled, nil,
+			"Contact changes require contact verification, which is not enabled")
+	}
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	query := `UPDATE users SET phone_number = ?, email = ? WHERE id = ?`
-	if err := s.session.Query(query, req.PhoneNumber, req.Email, req.Id).Exec(); err != nil {
-		log.Printf("Failed to update contact: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to update contact: %v", err)
+	// The codes are sent before the change is staged, so that a contact
+	// that could not be sent a code is never left pending.
+	staged, err := s.stagedContact(ctx, req.Id, req.PhoneNumber, req.Email, version)
+	if err != nil {
+		return nil, err
+	}
+	sent, err := s.sendPendingCodes(ctx, staged)
+	if err != nil {
+		return nil, err
 	}
+	u, err := s.repo.StageContact(ctx, req.Id, req.PhoneNumber, req.Email, version)
+	if err != nil {
+		for _, channel := range sent {
+			s.discardVerification(context.WithoutCancel(ctx), req.Id, channel)
+		}
+		return nil, apierror.FromRepository("update contact", err, req.Id, version)
+	}
+
+	return toUserResponse(u), nil
+}
 
-	// Fetch the updated user details
+// GetUser retrieves a user by phone number or email.
+func (s *UserServiceServer) GetUser(ctx context.Context, req *user.GetUserRequest) (*user.UserResponse, error) {
 	var (
-		firstName, lastName, gender, dateOfBirth, phoneNumber, email string
-		isBlocked                                                    bool
+		u   *repository.User
+		err error
 	)
-	if err := s.session.Query(`SELECT first_name, last_name, gender, date_of_birth, phone_number, email, is_blocked FROM users WHERE id = ?`, req.Id).Scan(
-		&firstName, &lastName, &gender, &dateOfBirth, &phoneNumber, &email, &isBlocked,
-	); err != nil {
-		log.Printf("Failed to fetch updated user: %v", err)
-		return nil, status.Errorf(codes.Internal, "Failed to fetch updated user: %v", err)
+	switch id := req.Identifier.(type) {
+	case *user.GetUserRequest_PhoneNumber:
+		u, err = s.repo.GetByPhoneNumber(ctx, id.PhoneNumber)
+	case *user.GetUserRequest_Email:
+		u, err = s.repo.GetByEmail(ctx, id.Email)
+	default:
+		return nil, apierror.InvalidArgument("Invalid request: phone_number or email is required",
+			apierror.FieldViolation("identifier", "phone_number or email is required"))
+	}
+	if err != nil {
+		return nil, apierror.FromRepository("fetch user", err, "", 0)
 	}
 
-	return &user.UserResponse{
-		Id:          req.Id,
-		FirstName:   firstName,
-		LastName:    lastName,
-		Gender:      gender,
-		DateOfBirth: dateOfBirth,
-		PhoneNumber: phoneNumber,
-		Email:       email,
-		IsBlocked:   isBlocked,
-	}, nil
+	return toUserResponse(u), nil
 }
 
-// GetUser retrieves a user by phone number or email.
-func (s *UserServiceServer) GetUser(ctx context.Context, req *user.GetUserRequest) (*user.UserResponse, error) {
-	// Validate the request
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %v", err)
+// ListUsers returns a page of users, optionally filtered by blocked state and gender.
+func (s *UserServiceServer) ListUsers(ctx context.Context, req *user.ListUsersRequest) (*user.ListUsersResponse, error) {
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
 	}
 
-	var (
-		id, firstName, lastName, gender, dateOfBirth, phoneNumber, email string
-		isBlocked                                                        bool
-	)
+	users, nextPageToken, err := s.repo.List(ctx, repository.ListOptions{
+		PageSize:  pageSize,
+		PageToken: req.PageToken,
+		IsBlocked: req.IsBlocked,
+		Gender:    req.Gender,
+	})
+	if err != nil {
+		return nil, apierror.FromRepository("list users", err, "", 0)
+	}
 
-	query := `SELECT id, first_name, last_name, gender, date_of_birth, phone_number, email, is_blocked FROM users WHERE phone_number = ? OR email = ? LIMIT 1`
-	if err := s.session.Query(query, req.PhoneNumber, req.Email).Scan(
-		&id, &firstName, &lastName, &gender, &dateOfBirth, &phoneNumber, &email, &isBlocked,
-	); err != nil {
-		log.Printf("Failed to fetch user: %v", err)
-		return nil, status.Errorf(codes.NotFound, "User not found: %v", err)
+	resp := &user.ListUsersResponse{NextPageToken: nextPageToken}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toUserResponse(u))
 	}
+	return resp, nil
+}
 
+// DeleteUser soft-deletes a user. The user is hidden from GetUser and
+// ListUsers but its record is kept until it is purged.
+func (s *UserServiceServer) DeleteUser(ctx context.Context, req *user.DeleteUserRequest) (*emptypb.Empty, error) {
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Delete(ctx, req.Id, version); err != nil {
+		return nil, apierror.FromRepository("delete user", err, req.Id, version)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// PurgeUser permanently removes a user and its email and phone lookup entries.
+func (s *UserServiceServer) PurgeUser(ctx context.Context, req *user.PurgeUserRequest) (*emptypb.Empty, error) {
+	if err := s.repo.Purge(ctx, req.Id); err != nil {
+		return nil, apierror.FromRepository("purge user", err, req.Id, 0)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toUserResponse(u *repository.User) *user.UserResponse {
 	return &user.UserResponse{
-		Id:          id,
-		FirstName:   firstName,
-		LastName:    lastName,
-		Gender:      gender,
-		DateOfBirth: dateOfBirth,
-		PhoneNumber: phoneNumber,
-		Email:       email,
-		IsBlocked:   isBlocked,
-	}, nil
+		Id:                 u.ID,
+		FirstName:          u.FirstName,
+		LastName:           u.LastName,
+		Gender:             u.Gender,
+		DateOfBirth:        u.DateOfBirth,
+		PhoneNumber:        u.PhoneNumber,
+		Email:              u.Email,
+		IsBlocked:          u.IsBlocked,
+		CreatedAt:          timestamppb.New(u.CreatedAt),
+		UpdatedAt:          timestamppb.New(u.UpdatedAt),
+		Version:            u.Version,
+		BlockReason:        u.BlockReason,
+		BlockedBy:          u.BlockedBy,
+		BlockedAt:          optionalTimestamp(u.BlockedAt),
+		BlockExpiresAt:     optionalTimestamp(u.BlockExpiresAt),
+		EmailVerified:      u.EmailVerified,
+		PhoneVerified:      u.PhoneVerified,
+		PendingEmail:       u.PendingEmail,
+		PendingPhoneNumber: u.PendingPhoneNumber,
+	}
+}
+
+// optionalTimestamp converts t to a Timestamp, leaving zero times unset.
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
 }
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"testing"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/notify"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+func createRequest() *user.CreateUserRequest {
+	return &user.CreateUserRequest{
+		FirstName:   "Jane",
+		LastName:    "Doe",
+		Gender:      "Female",
+		DateOfBirth: "1990-01-02",
+		PhoneNumber: "+4915100000001",
+		Email:       "jane@example.com",
+	}
+}
+
+func mustCreate(t *testing.T, s *UserServiceServer) *user.UserResponse {
+	t.Helper()
+	u, err := s.CreateUser(context.Background(), createRequest())
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return u
+}
+
+func withMetadata(kv ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+// assertStatus checks the code and ErrorInfo reason of a status error.
+func assertStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != code {
+		t.Fatalf("error = %v, want code %s", err, code)
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != reason {
+				t.Errorf("reason = %s, want %s", info.GetReason(), reason)
+			}
+			return
+		}
+	}
+	t.Errorf("error %v has no ErrorInfo, want reason %s", err, reason)
+}
+
+// violatedField returns the field of the first BadRequest violation of err.
+func violatedField(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok && len(br.GetFieldViolations()) > 0 {
+			return br.GetFieldViolations()[0].GetField()
+		}
+	}
+	return ""
+}
+
+func TestBlockedUserChanges(t *testing.T) {
+	ctx := context.Background()
+	s := NewUserServiceServer(memory.NewUserRepository(),
+		WithContactVerification(memory.NewVerificationStore(), notify.LogNotifier{}, time.Minute, 3, time.Minute))
+	created := mustCreate(t, s)
+	if _, err := s.BlockUser(ctx, &user.BlockUserRequest{Id: created.GetId(), Reason: "spam"}); err != nil {
+		t.Fatalf("BlockUser: %v", err)
+	}
+
+	_, err := s.UpdateUser(ctx, &user.UpdateUserRequest{Id: created.GetId(), FirstName: "Zoe", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name"}}})
+	assertStatus(t, err, codes.FailedPrecondition, apierror.ReasonUserBlocked)
+	_, err = s.UpdateContact(ctx, &user.UpdateContactRequest{Id: created.GetId(), PhoneNumber: created.GetPhoneNumber(), Email: "zoe@example.com"})
+	assertStatus(t, err, codes.FailedPrecondition, apierror.ReasonUserBlocked)
+	_, err = s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL})
+	assertStatus(t, err, codes.FailedPrecondition, apierror.ReasonUserBlocked)
+
+	if _, err := s.UnblockUser(ctx, &user.UnblockUserRequest{Id: created.GetId()}); err != nil {
+		t.Fatalf("UnblockUser: %v", err)
+	}
+	if _, err := s.UpdateUser(ctx, &user.UpdateUserRequest{Id: created.GetId(), FirstName: "Zoe", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"first_name"}}}); err != nil {
+		t.Errorf("UpdateUser of unblocked user: %v", err)
+	}
+}
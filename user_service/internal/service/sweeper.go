@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"log"
+	"time"
+	"user_service/internal/repository"
+)
+
+// defaultBlockSweepInterval is used by RunBlockSweeper when given no interval.
+const defaultBlockSweepInterval = time.Minute
+
+// RunBlockSweeper lifts expired timed blocks every interval until ctx is
+// cancelled.
+func (s *UserServiceServer) RunBlockSweeper(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultBlockSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.SweepExpiredBlocks(ctx); err != nil {
+			log.Printf("Failed to sweep expired blocks: %v", err)
+		} else if n > 0 {
+			log.Printf("Lifted %d expired blocks", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SweepExpiredBlocks unblocks every user whose timed block has expired and
+// returns how many users were unblocked. Each unblock is conditioned on the
+// version that was read, so a user re-blocked in the meantime keeps the new
+// block.
+func (s *UserServiceServer) SweepExpiredBlocks(ctx context.Context) (int, error) {
+	users, err := s.repo.ListExpiredBlocks(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	unblocked := 0
+	for _, u := range users {
+		unblockedUser, err := s.repo.Unblock(ctx, u.ID, u.Version)
+		if err != nil {
+			if errors.Is(err, repository.ErrVersionMismatch) || errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			log.Printf("Failed to lift expired block of user %s: %v", u.ID, err)
+			continue
+		}
+		s.recordBlockEvent(ctx, unblockedUser, repository.BlockActionUnblocked, "block expired", blockSweeperActor)
+		s.recordAudit(ctx, "SweepExpiredBlocks", blockSweeperActor, uuid.New().String(), u.ID, toUserResponse(u), toUserResponse(unblockedUser))
+		unblocked++
+	}
+	return unblocked, nil
+}
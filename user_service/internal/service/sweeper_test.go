@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+func TestSweepExpiredBlocks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewUserRepository()
+	s := NewUserServiceServer(repo)
+	timed := mustCreate(t, s)
+	other := createRequest()
+	other.Email, other.PhoneNumber = "other@example.com", "+4915100000002"
+	permanent, err := s.CreateUser(ctx, other)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+	blocked, err := s.BlockUser(withMetadata(actorKey, "admin@example.com"),
+		&user.BlockUserRequest{Id: timed.GetId(), Reason: "spam", ExpiresAt: timestamppb.New(expiresAt)})
+	if err != nil {
+		t.Fatalf("BlockUser: %v", err)
+	}
+	if !blocked.GetIsBlocked() || blocked.GetBlockReason() != "spam" || blocked.GetBlockedBy() != "admin@example.com" ||
+		!blocked.GetBlockExpiresAt().AsTime().Equal(expiresAt.UTC().Truncate(time.Millisecond)) {
+		t.Errorf("blocked user = %v, want blocked for spam by the caller's actor until %s", blocked, expiresAt)
+	}
+	if _, err := s.BlockUser(ctx, &user.BlockUserRequest{Id: permanent.GetId(), Reason: "fraud"}); err != nil {
+		t.Fatalf("BlockUser: %v", err)
+	}
+
+	if n, err := s.SweepExpiredBlocks(ctx); err != nil || n != 0 {
+		t.Fatalf("SweepExpiredBlocks before expiry = %d, %v; want 0, nil", n, err)
+	}
+	time.Sleep(time.Until(expiresAt) + 10*time.Millisecond)
+	if n, err := s.SweepExpiredBlocks(ctx); err != nil || n != 1 {
+		t.Fatalf("SweepExpiredBlocks after expiry = %d, %v; want 1, nil", n, err)
+	}
+	if u, _ := repo.Get(ctx, timed.GetId()); u.IsBlocked || u.BlockReason != "" || !u.BlockExpiresAt.IsZero() {
+		t.Errorf("user after expiry = blocked %v, reason %q, expiry %s; want unblocked", u.IsBlocked, u.BlockReason, u.BlockExpiresAt)
+	}
+	if u, _ := repo.Get(ctx, permanent.GetId()); !u.IsBlocked {
+		t.Error("block without expiry was lifted")
+	}
+}
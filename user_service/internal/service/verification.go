@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log"
+	"math/big"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/notify"
+	"user_service/internal/repository"
+	"user_service/protogen/user"
+)
+
+const (
+	// defaultCodeTTL is used when WithContactVerification is given no TTL.
+	defaultCodeTTL = 10 * time.Minute
+
+	// defaultMaxCodeAttempts is used when WithContactVerification is given no
+	// attempt limit.
+	defaultMaxCodeAttempts = 5
+
+	// defaultResendCooldown is used when WithContactVerification is given no
+	// resend cooldown.
+	defaultResendCooldown = time.Minute
+
+	// codeDigits is the length of the one-time codes.
+	codeDigits = 6
+)
+
+// WithContactVerification enables StartContactVerification and
+// ConfirmContactVerification. Codes are kept in store, delivered through
+// notifier, expire after codeTTL and may be confirmed at most maxAttempts
+// times. A new code for the same contact is sent at most once per
+// resendCooldown.
+func WithContactVerification(store repository.VerificationStore, notifier notify.Notifier, codeTTL time.Duration, maxAttempts int, resendCooldown time.Duration) Option {
+	return func(s *UserServiceServer) {
+		if codeTTL <= 0 {
+			codeTTL = defaultCodeTTL
+		}
+		if maxAttempts <= 0 {
+			maxAttempts = defaultMaxCodeAttempts
+		}
+		if resendCooldown <= 0 {
+			resendCooldown = defaultResendCooldown
+		}
+		s.verifications = store
+		s.notifier = notifier
+		s.codeTTL = codeTTL
+		s.maxCodeAttempts = maxAttempts
+		s.resendCooldown = resendCooldown
+	}
+}
+
+// StartContactVerification sends a one-time code to the user's pending email
+// or phone number, or to the current one if no change is pending. Starting
+// again replaces the previous code but keeps its attempt count, and is
+// refused within the resend cooldown and for blocked users.
+func (s *UserServiceServer) StartContactVerification(ctx context.Context, req *user.StartContactVerificationRequest) (*user.StartContactVerificationResponse, error) {
+	if s.verifications == nil {
+		return nil, apierror.New(codes.Unimplemented, apierror.ReasonNotEnabled, nil, "Contact verification is not enabled")
+	}
+
+	u, err := s.repo.Get(ctx, req.Id)
+	if err != nil {
+		return nil, apierror.FromRepository("fetch user", err, req.Id, 0)
+	}
+	if u.IsBlocked {
+		return nil, apierror.FromRepository("start contact verification", repository.ErrUserBlocked, req.Id, 0)
+	}
+	channel := contactChannel(req.Channel)
+	if _, verified := contact(u, channel); verified {
+		return nil, apierror.New(codes.FailedPrecondition, apierror.ReasonContactAlreadyVerified,
+			map[string]string{"user_id": req.Id, "channel": string(channel)},
+			fmt.Sprintf("The %s of user %s is already verified", contactField(channel), req.Id))
+	}
+	return s.sendCode(ctx, u, channel)
+}
+
+// stagedContact returns the user as StageContact would leave it, checking the
+// conditions StageContact enforces so that no code is sent for a change that
+// is bound to be refused. StageContact still checks them again.
+func (s *UserServiceServer) stagedContact(ctx context.Context, id, phoneNumber, email string, expectedVersion int64) (*repository.User, error) {
+	u, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, apierror.FromRepository("fetch user", err, id, expectedVersion)
+	}
+	switch {
+	case expectedVersion != 0 && u.Version != expectedVersion:
+		err = repository.ErrVersionMismatch
+	case u.IsBlocked:
+		err = repository.ErrUserBlocked
+	}
+	if err != nil {
+		return nil, apierror.FromRepository("update contact", err, id, expectedVersion)
+	}
+
+	u.PendingPhoneNumber, u.PendingEmail = "", ""
+	if u.PhoneNumber != phoneNumber {
+		owner, err := s.repo.GetByPhoneNumber(ctx, phoneNumber)
+		if err := checkContactOwner(owner, err, id, repository.ErrDuplicatePhoneNumber); err != nil {
+			return nil, err
+		}
+		u.PendingPhoneNumber = phoneNumber
+	}
+	if u.Email != email {
+		owner, err := s.repo.GetByEmail(ctx, email)
+		if err := checkContactOwner(owner, err, id, repository.ErrDuplicateEmail); err != nil {
+			return nil, err
+		}
+		u.PendingEmail = email
+	}
+	return u, nil
+}
+
+// checkContactOwner fails with duplicate if the user owning a contact, as
+// returned by a lookup, is not the user id.
+func checkContactOwner(owner *repository.User, err error, id string, duplicate error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return nil
+	case err != nil:
+		return apierror.Internal("look up contact owner", err)
+	case owner.ID != id:
+		return apierror.FromRepository("update contact", duplicate, id, 0)
+	}
+	return nil
+}
+
+// sendPendingCodes sends a code to each pending contact of u and returns the
+// channels it was sent on. If one cannot be sent, the codes already sent are
+// discarded.
+func (s *UserServiceServer) sendPendingCodes(ctx context.Context, u *repository.User) ([]repository.ContactChannel, error) {
+	var sent []repository.ContactChannel
+	for _, pending := range []struct {
+		channel repository.ContactChannel
+		value   string
+	}{
+		{repository.ContactChannelEmail, u.PendingEmail},
+		{repository.ContactChannelPhone, u.PendingPhoneNumber},
+	} {
+		if pending.value == "" {
+			continue
+		}
+		if _, err := s.sendCode(ctx, u, pending.channel); err != nil {
+			for _, c := range sent {
+				s.discardVerification(ctx, u.ID, c)
+			}
+			return nil, err
+		}
+		sent = append(sent, pending.channel)
+	}
+	return sent, nil
+}
+
+// sendCode stores a new one-time code for the channel's contact of u and
+// delivers it through the notifier. The attempts made on the code it replaces
+// carry over, so that resending does not allow more guesses.
+func (s *UserServiceServer) sendCode(ctx context.Context, u *repository.User, channel repository.ContactChannel) (*user.StartContactVerificationResponse, error) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	attempts, err := s.resendableAttempts(ctx, u.ID, channel, now)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, _ := contact(u, channel)
+	code, err := newCode()
+	if err != nil {
+		return nil, apierror.Internal("generate verification code", err)
+	}
+	v := &repository.Verification{
+		UserID:      u.ID,
+		Channel:     channel,
+		Destination: destination,
+		CodeHash:    hashCode(u.ID, channel, destination, code),
+		Attempts:    attempts,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.codeTTL),
+	}
+	if err := s.verifications.Put(ctx, v); err != nil {
+		return nil, apierror.Internal("store verification code", err)
+	}
+
+	msg := notify.Message{
+		Channel: string(channel),
+		To:      destination,
+		Subject: "Your verification code",
+		Body:    fmt.Sprintf("Your verification code is %s. It expires in %s.", code, s.codeTTL),
+		SentAt:  now,
+	}
+	if err := s.notifier.Send(ctx, msg); err != nil {
+		s.discardVerification(ctx, u.ID, channel)
+		return nil, apierror.Internal("send verification code", err)
+	}
+
+	return &user.StartContactVerificationResponse{
+		Channel:     protoContactChannel(channel),
+		Destination: destination,
+		ExpiresAt:   timestamppb.New(v.ExpiresAt),
+		MaxAttempts: int32(s.maxCodeAttempts),
+	}, nil
+}
+
+// resendableAttempts returns the attempts made on the pending verification of
+// the user and channel, or an error if no new code may be sent yet: within
+// the resend cooldown, or once the attempts are used up until the pending
+// code expires.
+func (s *UserServiceServer) resendableAttempts(ctx context.Context, id string, channel repository.ContactChannel, now time.Time) (int, error) {
+	previous, err := s.verifications.Get(ctx, id, channel)
+	if errors.Is(err, repository.ErrVerificationNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, apierror.Internal("fetch verification", err)
+	}
+	if previous.Attempts >= s.maxCodeAttempts {
+		return 0, apierror.New(codes.ResourceExhausted, apierror.ReasonTooManyAttempts,
+			map[string]string{"user_id": id, "channel": string(channel)},
+			fmt.Sprintf("Too many verification attempts, request a new code after %s", previous.ExpiresAt.Format(time.RFC3339)),
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(previous.ExpiresAt.Sub(now))})
+	}
+	if wait := previous.CreatedAt.Add(s.resendCooldown).Sub(now); wait > 0 {
+		return 0, apierror.New(codes.ResourceExhausted, apierror.ReasonResendTooSoon,
+			map[string]string{"user_id": id, "channel": string(channel)},
+			fmt.Sprintf("A verification code was sent less than %s ago", s.resendCooldown),
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)})
+	}
+	return previous.Attempts, nil
+}
+
+// ConfirmContactVerification marks the contact as verified if the code matches,
+// applying a pending contact change. After maxAttempts the code is refused
+// until it expires, and no new code can be requested before then.
+func (s *UserServiceServer) ConfirmContactVerification(ctx context.Context, req *user.ConfirmContactVerificationRequest) (*user.UserResponse, error) {
+	if s.verifications == nil {
+		return nil, apierror.New(codes.Unimplemented, apierror.ReasonNotEnabled, nil, "Contact verification is not enabled")
+	}
+
+	channel := contactChannel(req.Channel)
+	v, err := s.verifications.Get(ctx, req.Id, channel)
+	if err != nil {
+		return nil, apierror.FromRepository("fetch verification", err, req.Id, 0)
+	}
+	attempts, err := s.verifications.IncrementAttempts(ctx, req.Id, channel)
+	if err != nil {
+		return nil, apierror.FromRepository("count verification attempt", err, req.Id, 0)
+	}
+	if attempts > s.maxCodeAttempts {
+		return nil, apierror.New(codes.ResourceExhausted, apierror.ReasonTooManyAttempts, map[string]string{"user_id": req.Id},
+			fmt.Sprintf("Too many verification attempts, request a new code after %s", v.ExpiresAt.Format(time.RFC3339)),
+			&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Until(v.ExpiresAt))})
+	}
+
+	hash := hashCode(req.Id, channel, v.Destination, req.Code)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(v.CodeHash)) != 1 {
+		remaining := s.maxCodeAttempts - attempts
+		return nil, apierror.New(codes.InvalidArgument, apierror.ReasonInvalidVerificationCode,
+			map[string]string{"user_id": req.Id, "remaining_attempts": fmt.Sprint(remaining)},
+			"Invalid verification code", &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				apierror.FieldViolation("code", "code does not match the code that was sent"),
+			}})
+	}
+
+	u, err := s.repo.ConfirmContact(ctx, req.Id, channel, v.Destination)
+	if err != nil {
+		return nil, apierror.FromRepository("verify contact", err, req.Id, 0)
+	}
+	s.discardVerification(ctx, req.Id, channel)
+
+	return toUserResponse(u), nil
+}
+
+// discardVerification deletes a pending verification. A failure is only
+// logged: the code expires on its own.
+func (s *UserServiceServer) discardVerification(ctx context.Context, id string, channel repository.ContactChannel) {
+	if err := s.verifications.Delete(ctx, id, channel); err != nil {
+		log.Printf("Failed to discard %s verification of user %s: %v", channel, id, err)
+	}
+}
+
+func contactChannel(c user.ContactChannel) repository.ContactChannel {
+	if c == user.ContactChannel_PHONE {
+		return repository.ContactChannelPhone
+	}
+	return repository.ContactChannelEmail
+}
+
+func protoContactChannel(c repository.ContactChannel) user.ContactChannel {
+	if c == repository.ContactChannelPhone {
+		return user.ContactChannel_PHONE
+	}
+	return user.ContactChannel_EMAIL
+}
+
+// contact returns the value of the channel's contact that is to be verified,
+// the pending one if a change is pending, and whether it is verified.
+func contact(u *repository.User, channel repository.ContactChannel) (string, bool) {
+	if channel == repository.ContactChannelPhone {
+		if u.PendingPhoneNumber != "" {
+			return u.PendingPhoneNumber, false
+		}
+		return u.PhoneNumber, u.PhoneVerified
+	}
+	if u.PendingEmail != "" {
+		return u.PendingEmail, false
+	}
+	return u.Email, u.EmailVerified
+}
+
+// contactField returns the request field name of the channel's contact.
+func contactField(channel repository.ContactChannel) string {
+	if channel == repository.ContactChannelPhone {
+		return "phone_number"
+	}
+	return "email"
+}
+
+// newCode returns a random numeric one-time code.
+func newCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < codeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", codeDigits, n), nil
+}
+
+// hashCode binds a code to the user, channel and destination it was sent to,
+// so that it cannot confirm any other contact.
+func hashCode(userID string, channel repository.ContactChannel, destination, code string) string {
+	sum := sha256.Sum256([]byte(userID + "\x00" + string(channel) + "\x00" + destination + "\x00" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/notify"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+// testNotifier keeps the messages it is asked to send, or fails with err.
+type testNotifier struct {
+	mu       sync.Mutex
+	err      error
+	messages []notify.Message
+}
+
+func (n *testNotifier) Send(ctx context.Context, m notify.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.err != nil {
+		return n.err
+	}
+	n.messages = append(n.messages, m)
+	return nil
+}
+
+func (n *testNotifier) fail(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.err = err
+}
+
+var codePattern = regexp.MustCompile(`\b[0-9]{6}\b`)
+
+// lastCode returns the code of the last message sent to destination.
+func (n *testNotifier) lastCode(t *testing.T, destination string) string {
+	t.Helper()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := len(n.messages) - 1; i >= 0; i-- {
+		if m := n.messages[i]; m.To == destination {
+			return codePattern.FindString(m.Body)
+		}
+	}
+	t.Fatalf("no code was sent to %s", destination)
+	return ""
+}
+
+// wrongCode returns a code that differs from code.
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "111111"
+	}
+	return "000000"
+}
+
+func newVerifyingServer(maxAttempts int, resendCooldown time.Duration) (*UserServiceServer, *testNotifier) {
+	notifier := &testNotifier{}
+	return NewUserServiceServer(memory.NewUserRepository(),
+		WithContactVerification(memory.NewVerificationStore(), notifier, time.Minute, maxAttempts, resendCooldown)), notifier
+}
+
+func TestContactVerification(t *testing.T) {
+	ctx := context.Background()
+	s, notifier := newVerifyingServer(3, time.Minute)
+	created := mustCreate(t, s)
+
+	started, err := s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_PHONE})
+	if err != nil {
+		t.Fatalf("StartContactVerification: %v", err)
+	}
+	if started.GetDestination() != created.GetPhoneNumber() || started.GetMaxAttempts() != 3 {
+		t.Errorf("started = %v, want a code for %s with 3 attempts", started, created.GetPhoneNumber())
+	}
+	code := notifier.lastCode(t, created.GetPhoneNumber())
+
+	// A code only confirms the channel it was sent for.
+	_, err = s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL, Code: code})
+	assertStatus(t, err, codes.FailedPrecondition, apierror.ReasonVerificationNotFound)
+	_, err = s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_PHONE, Code: wrongCode(code)})
+	assertStatus(t, err, codes.InvalidArgument, apierror.ReasonInvalidVerificationCode)
+
+	confirmed, err := s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_PHONE, Code: code})
+	if err != nil {
+		t.Fatalf("ConfirmContactVerification: %v", err)
+	}
+	if !confirmed.GetPhoneVerified() || confirmed.GetEmailVerified() || confirmed.GetPhoneNumber() != created.GetPhoneNumber() {
+		t.Errorf("confirmed user = phone verified %v, email verified %v; want only the phone verified",
+			confirmed.GetPhoneVerified(), confirmed.GetEmailVerified())
+	}
+
+	_, err = s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_PHONE})
+	assertStatus(t, err, codes.FailedPrecondition, apierror.ReasonContactAlreadyVerified)
+	_, err = s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_PHONE, Code: code})
+	assertStatus(t, err, codes.FailedPrecondition, apierror.ReasonVerificationNotFound)
+}
+
+func TestContactVerificationAttempts(t *testing.T) {
+	ctx := context.Background()
+	s, notifier := newVerifyingServer(3, time.Millisecond)
+	created := mustCreate(t, s)
+	if _, err := s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL}); err != nil {
+		t.Fatalf("StartContactVerification: %v", err)
+	}
+	code := notifier.lastCode(t, created.GetEmail())
+
+	for i := 0; i < 2; i++ {
+		_, err := s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL, Code: wrongCode(code)})
+		assertStatus(t, err, codes.InvalidArgument, apierror.ReasonInvalidVerificationCode)
+	}
+
+	// A resent code keeps the attempts made on the previous one.
+	time.Sleep(2 * time.Millisecond)
+	if _, err := s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL}); err != nil {
+		t.Fatalf("StartContactVerification again: %v", err)
+	}
+	code = notifier.lastCode(t, created.GetEmail())
+	_, err := s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL, Code: wrongCode(code)})
+	assertStatus(t, err, codes.InvalidArgument, apierror.ReasonInvalidVerificationCode)
+
+	// The attempts are used up, so even the right code is refused, and no new
+	// code is sent until it expires.
+	_, err = s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL, Code: code})
+	assertStatus(t, err, codes.ResourceExhausted, apierror.ReasonTooManyAttempts)
+	time.Sleep(2 * time.Millisecond)
+	_, err = s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL})
+	assertStatus(t, err, codes.ResourceExhausted, apierror.ReasonTooManyAttempts)
+}
+
+func TestContactVerificationResendCooldown(t *testing.T) {
+	ctx := context.Background()
+	s, notifier := newVerifyingServer(3, time.Hour)
+	created := mustCreate(t, s)
+	if _, err := s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL}); err != nil {
+		t.Fatalf("StartContactVerification: %v", err)
+	}
+	code := notifier.lastCode(t, created.GetEmail())
+
+	_, err := s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL})
+	assertStatus(t, err, codes.ResourceExhausted, apierror.ReasonResendTooSoon)
+	// The cooldown is kept per contact, and the code sent before still works.
+	if _, err := s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_PHONE}); err != nil {
+		t.Errorf("StartContactVerification of the phone number: %v", err)
+	}
+	if _, err := s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Channel: user.ContactChannel_EMAIL, Code: code}); err != nil {
+		t.Errorf("ConfirmContactVerification: %v", err)
+	}
+}
+
+func TestContactVerificationNotEnabled(t *testing.T) {
+	ctx := context.Background()
+	s := NewUserServiceServer(memory.NewUserRepository())
+	created := mustCreate(t, s)
+
+	_, err := s.StartContactVerification(ctx, &user.StartContactVerificationRequest{Id: created.GetId()})
+	assertStatus(t, err, codes.Unimplemented, apierror.ReasonNotEnabled)
+	_, err = s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{Id: created.GetId(), Code: "123456"})
+	assertStatus(t, err, codes.Unimplemented, apierror.ReasonNotEnabled)
+	_, err = s.UpdateContact(ctx, &user.UpdateContactRequest{Id: created.GetId(), Email: "zoe@example.com"})
+	assertStatus(t, err, codes.Unimplemented, apierror.ReasonNotEnabled)
+}
+
+func TestUpdateContact(t *testing.T) {
+	ctx := context.Background()
+	s, notifier := newVerifyingServer(3, time.Minute)
+	created := mustCreate(t, s)
+
+	staged, err := s.UpdateContact(ctx, &user.UpdateContactRequest{Id: created.GetId(), PhoneNumber: created.GetPhoneNumber(), Email: "zoe@example.com"})
+	if err != nil {
+		t.Fatalf("UpdateContact: %v", err)
+	}
+	if staged.GetEmail() != created.GetEmail() || staged.GetPendingEmail() != "zoe@example.com" || staged.GetPendingPhoneNumber() != "" {
+		t.Errorf("staged user = email %q, pending email %q, pending phone %q; want only zoe@example.com pending",
+			staged.GetEmail(), staged.GetPendingEmail(), staged.GetPendingPhoneNumber())
+	}
+
+	confirmed, err := s.ConfirmContactVerification(ctx, &user.ConfirmContactVerificationRequest{
+		Id: created.GetId(), Channel: user.ContactChannel_EMAIL, Code: notifier.lastCode(t, "zoe@example.com")})
+	if err != nil {
+		t.Fatalf("ConfirmContactVerification: %v", err)
+	}
+	if confirmed.GetEmail() != "zoe@example.com" || confirmed.GetPendingEmail() != "" || !confirmed.GetEmailVerified() {
+		t.Errorf("confirmed user = email %q, pending %q, verified %v; want zoe@example.com verified",
+			confirmed.GetEmail(), confirmed.GetPendingEmail(), confirmed.GetEmailVerified())
+	}
+}
+
+func TestUpdateContactNotSent(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewUserRepository()
+	notifier := &testNotifier{}
+	s := NewUserServiceServer(repo, WithContactVerification(memory.NewVerificationStore(), notifier, time.Minute, 3, time.Minute))
+	created := mustCreate(t, s)
+	other, err := s.CreateUser(ctx, &user.CreateUserRequest{FirstName: "John", LastName: "Roe", Gender: "Male",
+		DateOfBirth: "1980-05-06", PhoneNumber: "+4915100000002", Email: "john@example.com"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	recorded, err := repo.PendingEvents(ctx, 100)
+	if err != nil {
+		t.Fatalf("PendingEvents: %v", err)
+	}
+
+	// A contact owned by another user is refused without sending a code.
+	_, err = s.UpdateContact(ctx, &user.UpdateContactRequest{Id: created.GetId(), PhoneNumber: created.GetPhoneNumber(), Email: other.GetEmail()})
+	assertStatus(t, err, codes.AlreadyExists, apierror.ReasonDuplicateEmail)
+	if len(notifier.messages) != 0 {
+		t.Errorf("sent %d codes for a duplicate email, want none", len(notifier.messages))
+	}
+
+	// A contact that cannot be sent a code is never staged.
+	notifier.fail(errors.New("mail server down"))
+	_, err = s.UpdateContact(ctx, &user.UpdateContactRequest{Id: created.GetId(), PhoneNumber: "+4915100000003", Email: "zoe@example.com"})
+	assertStatus(t, err, codes.Internal, apierror.ReasonInternal)
+
+	got, err := repo.Get(ctx, created.GetId())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Version != created.GetVersion() || got.PendingEmail != "" || got.PendingPhoneNumber != "" {
+		t.Errorf("user = version %d, pending %q and %q; want version %d with nothing pending",
+			got.Version, got.PendingEmail, got.PendingPhoneNumber, created.GetVersion())
+	}
+	if events, err := repo.PendingEvents(ctx, 100); err != nil || len(events) != len(recorded) {
+		t.Errorf("recorded %d events, %v; want %d", len(events), err, len(recorded))
+	}
+}
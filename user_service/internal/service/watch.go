@@ -0,0 +1,116 @@
+package service
+
+import (
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"user_service/internal/apierror"
+	"user_service/internal/events"
+	"user_service/protogen/user"
+)
+
+// lastEventIDKey is the metadata key the REST gateway forwards the
+// Last-Event-ID header of a reconnecting Server-Sent Events client under.
+const lastEventIDKey = "last-event-id"
+
+// watchBuffer is how many events a WatchUsers stream may fall behind before
+// it is ended.
+const watchBuffer = 256
+
+// WithChangeFeed enables WatchUsers, streaming the events published to feed.
+func WithChangeFeed(feed *events.Broker) Option {
+	return func(s *UserServiceServer) {
+		s.feed = feed
+	}
+}
+
+// WatchUsers streams user events as they are published, optionally only those
+// of the given users and event types. Every event carries a cursor; a stream
+// resumed with it continues right after that event. A stream that falls too
+// far behind is ended with Aborted and can be resumed from its last cursor.
+func (s *UserServiceServer) WatchUsers(req *user.WatchUsersRequest, stream user.UserService_WatchUsersServer) error {
+	if s.feed == nil {
+		return apierror.New(codes.Unimplemented, apierror.ReasonNotEnabled, nil, "Watching users is not enabled")
+	}
+	ctx := stream.Context()
+
+	cursor := req.Cursor
+	if cursor == "" {
+		cursor = firstMetadata(ctx, lastEventIDKey)
+	}
+	sub, err := s.feed.Subscribe(cursor, watchBuffer)
+	switch {
+	case errors.Is(err, events.ErrInvalidCursor):
+		return apierror.InvalidArgument("Invalid request: invalid cursor",
+			apierror.FieldViolation("cursor", "cursor was not returned by a previous WatchUsers call"))
+	case errors.Is(err, events.ErrCursorExpired):
+		return apierror.New(codes.OutOfRange, apierror.ReasonCursorExpired, nil,
+			"The cursor has expired, start a new watch without a cursor")
+	case err != nil:
+		return apierror.Internal("subscribe to user events", err)
+	}
+	defer sub.Close()
+
+	users := make(map[string]bool, len(req.UserIds))
+	for _, id := range req.UserIds {
+		users[id] = true
+	}
+	types := make(map[user.EventType]bool, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		types[t] = true
+	}
+
+	var last string
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case env, ok := <-sub.Events():
+			if !ok {
+				return watchEnded(sub.Err(), last)
+			}
+			last = env.Cursor
+			if len(users) > 0 && !users[env.Event.GetUserId()] {
+				continue
+			}
+			if len(types) > 0 && !types[eventType(env.Event)] {
+				continue
+			}
+			if err := stream.Send(&user.WatchUsersResponse{Event: env.Event, Cursor: env.Cursor}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchEnded reports why the broker ended a subscription. last is the cursor
+// of the last event seen, filtered out or not, from which the watch can be
+// resumed.
+func watchEnded(err error, last string) error {
+	metadata := map[string]string{}
+	if last != "" {
+		metadata["cursor"] = last
+	}
+	if errors.Is(err, events.ErrSubscriberTooSlow) {
+		return apierror.New(codes.Aborted, apierror.ReasonWatchTooSlow, metadata,
+			"The watch fell too far behind, resume it from the last cursor")
+	}
+	return apierror.New(codes.Unavailable, apierror.ReasonShuttingDown, metadata, "The server is shutting down")
+}
+
+func eventType(e *user.UserEvent) user.EventType {
+	switch e.GetEvent().(type) {
+	case *user.UserEvent_UserCreated:
+		return user.EventType_USER_CREATED
+	case *user.UserEvent_UserUpdated:
+		return user.EventType_USER_UPDATED
+	case *user.UserEvent_UserBlocked:
+		return user.EventType_USER_BLOCKED
+	case *user.UserEvent_UserUnblocked:
+		return user.EventType_USER_UNBLOCKED
+	case *user.UserEvent_ContactChanged:
+		return user.EventType_CONTACT_CHANGED
+	default:
+		return user.EventType_EVENT_TYPE_UNSPECIFIED
+	}
+}
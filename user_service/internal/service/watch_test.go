@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"testing"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/events"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+// watchStream is a WatchUsers server stream handing the sent responses to
+// the test.
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *user.WatchUsersResponse
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(resp *user.WatchUsersResponse) error {
+	s.sent <- resp
+	return nil
+}
+
+// watch runs WatchUsers in the background and returns its stream and the
+// channel its result is delivered on.
+func watch(ctx context.Context, s *UserServiceServer, req *user.WatchUsersRequest) (*watchStream, <-chan error) {
+	stream := &watchStream{ctx: ctx, sent: make(chan *user.WatchUsersResponse, 10)}
+	done := make(chan error, 1)
+	go func() { done <- s.WatchUsers(req, stream) }()
+	return stream, done
+}
+
+func (s *watchStream) next(t *testing.T) *user.WatchUsersResponse {
+	t.Helper()
+	select {
+	case resp := <-s.sent:
+		return resp
+	case <-time.After(time.Second):
+		t.Fatal("no event was streamed")
+		return nil
+	}
+}
+
+func watchEvent(id, userID string) *user.UserEvent {
+	return &user.UserEvent{EventId: id, UserId: userID, Event: &user.UserEvent_UserUpdated{UserUpdated: &user.UserUpdated{}}}
+}
+
+// publishWhenSubscribed publishes the events once the watch has subscribed,
+// which it notices by a probe event reaching the stream.
+func publishWhenSubscribed(t *testing.T, feed *events.Broker, stream *watchStream, es ...*user.UserEvent) {
+	t.Helper()
+	ctx := context.Background()
+	deadline := time.Now().Add(time.Second)
+	for i := 0; ; i++ {
+		feed.Publish(ctx, &user.UserEvent{EventId: "probe-" + strconv.Itoa(i), UserId: "probe",
+			Event: &user.UserEvent_UserCreated{UserCreated: &user.UserCreated{}}})
+		select {
+		case <-stream.sent:
+			for _, e := range es {
+				feed.Publish(ctx, e)
+			}
+			return
+		case <-time.After(time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the watch did not subscribe")
+		}
+	}
+}
+
+func TestWatchUsers(t *testing.T) {
+	feed := events.NewBroker(1000)
+	s := NewUserServiceServer(memory.NewUserRepository(), WithChangeFeed(feed))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, done := watch(ctx, s, &user.WatchUsersRequest{
+		UserIds:    []string{"probe", "u1"},
+		EventTypes: []user.EventType{user.EventType_USER_CREATED, user.EventType_USER_UPDATED},
+	})
+	publishWhenSubscribed(t, feed, stream,
+		watchEvent("e1", "u2"),
+		&user.UserEvent{EventId: "e2", UserId: "u1", Event: &user.UserEvent_UserBlocked{UserBlocked: &user.UserBlocked{}}},
+		watchEvent("e3", "u1"),
+		watchEvent("e4", "u1"),
+	)
+	first := stream.next(t)
+	if first.GetEvent().GetEventId() != "e3" || first.GetCursor() == "" {
+		t.Fatalf("first event = %v, want e3 with a cursor", first)
+	}
+	if next := stream.next(t); next.GetEvent().GetEventId() != "e4" {
+		t.Fatalf("second event = %v, want e4", next)
+	}
+	cancel()
+	if err := <-done; status.Code(err) != codes.Canceled {
+		t.Fatalf("WatchUsers after the stream was cancelled = %v, want %s", err, codes.Canceled)
+	}
+
+	// A watch resumed from a cursor, here through the Last-Event-ID the
+	// gateway forwards, continues right after that event.
+	ctx, cancel = context.WithCancel(metadata.NewIncomingContext(context.Background(), metadata.Pairs(lastEventIDKey, first.GetCursor())))
+	defer cancel()
+	resumed, _ := watch(ctx, s, &user.WatchUsersRequest{})
+	if got := resumed.next(t).GetEvent().GetEventId(); got != "e4" {
+		t.Errorf("resumed watch started with %s, want e4", got)
+	}
+}
+
+func TestWatchUsersErrors(t *testing.T) {
+	ctx := context.Background()
+	_, done := watch(ctx, NewUserServiceServer(memory.NewUserRepository()), &user.WatchUsersRequest{})
+	assertStatus(t, <-done, codes.Unimplemented, apierror.ReasonNotEnabled)
+
+	feed := events.NewBroker(10)
+	s := NewUserServiceServer(memory.NewUserRepository(), WithChangeFeed(feed))
+	_, done = watch(ctx, s, &user.WatchUsersRequest{Cursor: "not a cursor"})
+	assertStatus(t, <-done, codes.InvalidArgument, apierror.ReasonValidationFailed)
+	other := events.NewBroker(10)
+	sub, err := other.Subscribe("", 1)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	other.Publish(ctx, watchEvent("e1", "u1"))
+	_, done = watch(ctx, s, &user.WatchUsersRequest{Cursor: (<-sub.Events()).Cursor})
+	assertStatus(t, <-done, codes.OutOfRange, apierror.ReasonCursorExpired)
+
+	feed.Close()
+	_, done = watch(ctx, s, &user.WatchUsersRequest{})
+	assertStatus(t, <-done, codes.Unavailable, apierror.ReasonShuttingDown)
+}
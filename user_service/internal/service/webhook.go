@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net/url"
+	"time"
+	"user_service/internal/apierror"
+	"user_service/internal/repository"
+	"user_service/internal/webhook"
+	"user_service/protogen/user"
+)
+
+// eventTypes maps the API event types to those recorded by the outbox.
+var eventTypes = map[user.EventType]repository.EventType{
+	user.EventType_USER_CREATED:    repository.EventUserCreated,
+	user.EventType_USER_UPDATED:    repository.EventUserUpdated,
+	user.EventType_USER_BLOCKED:    repository.EventUserBlocked,
+	user.EventType_USER_UNBLOCKED:  repository.EventUserUnblocked,
+	user.EventType_CONTACT_CHANGED: repository.EventContactChanged,
+}
+
+// deliveryStatuses maps the API delivery statuses to the stored ones.
+var deliveryStatuses = map[user.DeliveryStatus]repository.DeliveryStatus{
+	user.DeliveryStatus_PENDING:     repository.DeliveryPending,
+	user.DeliveryStatus_SUCCEEDED:   repository.DeliverySucceeded,
+	user.DeliveryStatus_DEAD_LETTER: repository.DeliveryDeadLetter,
+}
+
+// WithWebhooks enables the webhook RPCs, managing the subscriptions in store.
+// The deliveries themselves are made by a webhook.Dispatcher. URLs reaching
+// internal addresses are rejected unless allowPrivateNetworks is set.
+func WithWebhooks(store repository.WebhookStore, allowPrivateNetworks bool) Option {
+	return func(s *UserServiceServer) {
+		s.webhooks = store
+		s.allowPrivateWebhooks = allowPrivateNetworks
+	}
+}
+
+// CreateWebhook subscribes a URL to the requested event types. Only events
+// recorded after the subscription are delivered.
+func (s *UserServiceServer) CreateWebhook(ctx context.Context, req *user.CreateWebhookRequest) (*user.Webhook, error) {
+	if s.webhooks == nil {
+		return nil, webhooksNotEnabled()
+	}
+	if u, err := url.Parse(req.Url); err != nil || u.Host == "" {
+		return nil, apierror.InvalidArgument("Invalid request: invalid url",
+			apierror.FieldViolation("url", "value must be an absolute HTTP or HTTPS URL"))
+	}
+	if err := webhook.CheckURL(ctx, req.Url, s.allowPrivateWebhooks); err != nil {
+		if errors.Is(err, webhook.ErrDisallowedAddress) {
+			return nil, apierror.InvalidArgument("Invalid request: url must not reach a loopback, private or link-local address",
+				apierror.FieldViolation("url", "host must resolve to public addresses only"))
+		}
+		return nil, apierror.InvalidArgument("Invalid request: url host cannot be resolved",
+			apierror.FieldViolation("url", "host cannot be resolved"))
+	}
+
+	w := &repository.Webhook{
+		ID:        uuid.New().String(),
+		URL:       req.Url,
+		Secret:    req.Secret,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	seen := make(map[user.EventType]bool)
+	for _, t := range req.EventTypes {
+		if !seen[t] {
+			seen[t] = true
+			w.EventTypes = append(w.EventTypes, eventTypes[t])
+		}
+	}
+	if err := s.webhooks.CreateWebhook(ctx, w); err != nil {
+		return nil, apierror.Internal("create webhook", err)
+	}
+	return toWebhook(w), nil
+}
+
+func (s *UserServiceServer) GetWebhook(ctx context.Context, req *user.GetWebhookRequest) (*user.Webhook, error) {
+	if s.webhooks == nil {
+		return nil, webhooksNotEnabled()
+	}
+	w, err := s.webhooks.GetWebhook(ctx, req.Id)
+	if err != nil {
+		return nil, apierror.FromWebhookStore("get webhook", err, req.Id)
+	}
+	return toWebhook(w), nil
+}
+
+func (s *UserServiceServer) ListWebhooks(ctx context.Context, req *user.ListWebhooksRequest) (*user.ListWebhooksResponse, error) {
+	if s.webhooks == nil {
+		return nil, webhooksNotEnabled()
+	}
+	webhooks, err := s.webhooks.ListWebhooks(ctx)
+	if err != nil {
+		return nil, apierror.Internal("list webhooks", err)
+	}
+	resp := &user.ListWebhooksResponse{}
+	for _, w := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, toWebhook(w))
+	}
+	return resp, nil
+}
+
+// DeleteWebhook removes the subscription. Its pending deliveries are
+// dead-lettered when they come due; the delivery log is kept.
+func (s *UserServiceServer) DeleteWebhook(ctx context.Context, req *user.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	if s.webhooks == nil {
+		return nil, webhooksNotEnabled()
+	}
+	if err := s.webhooks.DeleteWebhook(ctx, req.Id); err != nil {
+		return nil, apierror.FromWebhookStore("delete webhook", err, req.Id)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListWebhookDeliveries returns the delivery log of a webhook, newest event
+// first, optionally only the deliveries in one status such as DEAD_LETTER.
+func (s *UserServiceServer) ListWebhookDeliveries(ctx context.Context, req *user.ListWebhookDeliveriesRequest) (*user.ListWebhookDeliveriesResponse, error) {
+	if s.webhooks == nil {
+		return nil, webhooksNotEnabled()
+	}
+	if _, err := s.webhooks.GetWebhook(ctx, req.WebhookId); err != nil {
+		return nil, apierror.FromWebhookStore("get webhook", err, req.WebhookId)
+	}
+
+	q := repository.DeliveryQuery{
+		WebhookID: req.WebhookId,
+		Status:    deliveryStatuses[req.Status],
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	}
+	if q.PageSize == 0 {
+		q.PageSize = defaultPageSize
+	}
+	deliveries, nextPageToken, err := s.webhooks.ListDeliveries(ctx, q)
+	if err != nil {
+		return nil, apierror.FromWebhookStore("list webhook deliveries", err, req.WebhookId)
+	}
+
+	resp := &user.ListWebhookDeliveriesResponse{NextPageToken: nextPageToken}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, toWebhookDelivery(d))
+	}
+	return resp, nil
+}
+
+func webhooksNotEnabled() error {
+	return apierror.New(codes.Unimplemented, apierror.ReasonNotEnabled, nil, "Webhooks are not enabled")
+}
+
+func toWebhook(w *repository.Webhook) *user.Webhook {
+	out := &user.Webhook{Id: w.ID, Url: w.URL, CreatedAt: timestamppb.New(w.CreatedAt)}
+	for _, t := range w.EventTypes {
+		out.EventTypes = append(out.EventTypes, protoEventType(t))
+	}
+	return out
+}
+
+func toWebhookDelivery(d *repository.Delivery) *user.WebhookDelivery {
+	out := &user.WebhookDelivery{
+		WebhookId:     d.WebhookID,
+		EventId:       d.EventID,
+		UserId:        d.UserID,
+		EventType:     protoEventType(d.EventType),
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+		NextAttemptAt: optionalTimestamp(d.NextAttemptAt),
+	}
+	for status, stored := range deliveryStatuses {
+		if stored == d.Status {
+			out.Status = status
+		}
+	}
+	for _, a := range d.Attempts {
+		out.Attempts = append(out.Attempts, &user.DeliveryAttempt{
+			AttemptedAt: timestamppb.New(a.AttemptedAt),
+			StatusCode:  int32(a.StatusCode),
+			Error:       a.Error,
+			DurationMs:  a.Duration.Milliseconds(),
+		})
+	}
+	return out
+}
+
+func protoEventType(t repository.EventType) user.EventType {
+	for et, stored := range eventTypes {
+		if stored == t {
+			return et
+		}
+	}
+	return user.EventType_EVENT_TYPE_UNSPECIFIED
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrDisallowedAddress is returned for webhook URLs, and connections, that
+// reach a loopback, private, link-local or otherwise internal address. Such
+// webhooks would let API clients make the service call internal endpoints,
+// such as the cloud metadata service at 169.254.169.254.
+var ErrDisallowedAddress = errors.New("webhook address is not publicly routable")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which
+// netip.Addr.IsPrivate does not cover.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// CheckURL resolves the host of a webhook URL and returns ErrDisallowedAddress
+// if any of its addresses is internal. allowPrivate skips the check, for
+// development setups posting to local receivers.
+func CheckURL(ctx context.Context, rawURL string, allowPrivate bool) error {
+	if allowPrivate {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", u.Hostname(), err)
+	}
+	for _, addr := range addrs {
+		if !publicAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrDisallowedAddress, u.Hostname(), addr)
+		}
+	}
+	return nil
+}
+
+// NewClient returns the HTTP client deliveries are posted with. Unless
+// allowPrivate is set, it refuses to connect to internal addresses, which
+// also covers hosts whose DNS records changed after CheckURL and redirects.
+// Proxies from the environment are ignored, as they would hide the address.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = dialControl
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// dialControl rejects connections to internal addresses. It runs after name
+// resolution, on the address actually dialed.
+func dialControl(network, address string, c syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !publicAddr(ap.Addr()) {
+		return fmt.Errorf("%w: %s", ErrDisallowedAddress, ap.Addr())
+	}
+	return nil
+}
+
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
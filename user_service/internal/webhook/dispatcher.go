@@ -0,0 +1,305 @@
+// Package webhook delivers user events to the URLs subscribed to them.
+//
+// The Dispatcher is an events.EventPublisher: publishing an event enqueues one
+// delivery per subscribed webhook, and Run posts the due deliveries, retrying
+// failed ones with exponential backoff until they succeed or are dead-lettered.
+// Every attempt is kept in the delivery log of the WebhookStore.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+	"user_service/internal/events"
+	"user_service/internal/repository"
+	"user_service/protogen/user"
+)
+
+const (
+	// defaultMaxAttempts is used by NewDispatcher when given no maximum.
+	defaultMaxAttempts = 8
+
+	// defaultInitialBackoff is used by NewDispatcher when given no initial
+	// backoff.
+	defaultInitialBackoff = 30 * time.Second
+
+	// defaultMaxBackoff is used by NewDispatcher when given no maximum
+	// backoff.
+	defaultMaxBackoff = time.Hour
+
+	// defaultDispatchInterval is used by Run when given no interval.
+	defaultDispatchInterval = time.Second
+
+	// defaultBatchSize is used by Run when given no batch size.
+	defaultBatchSize = 100
+
+	// defaultConcurrency is used by NewDispatcher when given no concurrency.
+	defaultConcurrency = 10
+
+	// maxResponseBody is how much of a response is read before the
+	// connection is released.
+	maxResponseBody = 64 << 10
+)
+
+// Dispatcher enqueues and posts webhook deliveries.
+type Dispatcher struct {
+	store          repository.WebhookStore
+	client         *http.Client
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	concurrency    int
+}
+
+var _ events.EventPublisher = (*Dispatcher)(nil)
+
+// NewDispatcher returns a dispatcher posting through client to up to
+// concurrency webhooks at a time. A delivery is attempted up to maxAttempts
+// times; the n-th retry waits initialBackoff * 2^(n-1), capped at maxBackoff.
+func NewDispatcher(store repository.WebhookStore, client *http.Client, maxAttempts int, initialBackoff, maxBackoff time.Duration, concurrency int) *Dispatcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	if initialBackoff <= 0 {
+		initialBackoff = defaultInitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	return &Dispatcher{
+		store:          store,
+		client:         client,
+		maxAttempts:    maxAttempts,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		concurrency:    concurrency,
+	}
+}
+
+// Publish enqueues a delivery of the event to every webhook subscribed to its
+// type. The deliveries are due immediately.
+func (d *Dispatcher) Publish(ctx context.Context, e *user.UserEvent) error {
+	t := eventType(e)
+	webhooks, err := d.store.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	for _, w := range webhooks {
+		if !w.Subscribed(t) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = protojson.Marshal(e); err != nil {
+				return err
+			}
+		}
+		delivery := &repository.Delivery{
+			WebhookID:     w.ID,
+			EventID:       e.GetEventId(),
+			UserID:        e.GetUserId(),
+			EventType:     t,
+			Payload:       payload,
+			Status:        repository.DeliveryPending,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		}
+		if err := d.store.EnqueueDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run posts the due deliveries every interval until ctx is cancelled. Full
+// batches are followed by the next batch right away.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration, batchSize int) {
+	if interval <= 0 {
+		interval = defaultDispatchInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.DeliverDue(ctx, batchSize)
+		if err != nil {
+			log.Printf("Failed to deliver webhooks: %v", err)
+		}
+		if err == nil && n == batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue makes one attempt at up to batchSize due deliveries and returns
+// how many were attempted. The deliveries of a webhook are posted in order by
+// one worker, so that a slow webhook only holds up its own deliveries, and up
+// to the dispatcher's concurrency webhooks are posted to at a time. A storage
+// error stops the worker that hit it and is returned once the others are done.
+func (d *Dispatcher) DeliverDue(ctx context.Context, batchSize int) (int, error) {
+	due, err := d.store.DueDeliveries(ctx, time.Now().UTC(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var queues [][]*repository.Delivery
+	byWebhook := make(map[string]int)
+	for _, delivery := range due {
+		i, ok := byWebhook[delivery.WebhookID]
+		if !ok {
+			i = len(queues)
+			byWebhook[delivery.WebhookID] = i
+			queues = append(queues, nil)
+		}
+		queues[i] = append(queues[i], delivery)
+	}
+
+	var (
+		mu        sync.Mutex
+		attempted int
+		firstErr  error
+		wg        sync.WaitGroup
+	)
+	work := make(chan []*repository.Delivery)
+	for i := 0; i < d.concurrency && i < len(queues); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for queue := range work {
+				for _, delivery := range queue {
+					err := d.deliver(ctx, delivery)
+					mu.Lock()
+					if err != nil && firstErr == nil {
+						firstErr = err
+					}
+					if err == nil {
+						attempted++
+					}
+					mu.Unlock()
+					if err != nil {
+						break
+					}
+				}
+			}
+		}()
+	}
+	for _, queue := range queues {
+		work <- queue
+	}
+	close(work)
+	wg.Wait()
+	return attempted, firstErr
+}
+
+// deliver makes one attempt at the delivery and stores its outcome. Only
+// storage errors are returned; a failed attempt is recorded in the delivery.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *repository.Delivery) error {
+	w, err := d.store.GetWebhook(ctx, delivery.WebhookID)
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		delivery.Status, delivery.NextAttemptAt = repository.DeliveryDeadLetter, time.Time{}
+		return d.store.UpdateDelivery(ctx, delivery)
+	}
+	if err != nil {
+		return err
+	}
+
+	attempt := d.post(ctx, w, delivery)
+	delivery.Attempts = append(delivery.Attempts, attempt)
+	switch {
+	case attempt.Error == "":
+		delivery.Status, delivery.NextAttemptAt = repository.DeliverySucceeded, time.Time{}
+	case len(delivery.Attempts) >= d.maxAttempts:
+		delivery.Status, delivery.NextAttemptAt = repository.DeliveryDeadLetter, time.Time{}
+		log.Printf("Dead-lettered delivery of event %s to webhook %s after %d attempts: %s",
+			delivery.EventID, w.ID, len(delivery.Attempts), attempt.Error)
+	default:
+		delivery.NextAttemptAt = attempt.AttemptedAt.Add(d.backoff(len(delivery.Attempts)))
+	}
+	return d.store.UpdateDelivery(ctx, delivery)
+}
+
+// post sends the signed payload to the webhook. Responses other than 2xx
+// count as failures.
+func (d *Dispatcher) post(ctx context.Context, w *repository.Webhook, delivery *repository.Delivery) repository.DeliveryAttempt {
+	start := time.Now().UTC()
+	attempt := repository.DeliveryAttempt{AttemptedAt: start.Truncate(time.Millisecond)}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "user_service-webhooks")
+	req.Header.Set(SignatureHeader, Sign(w.Secret, start, delivery.Payload))
+	req.Header.Set(WebhookIDHeader, w.ID)
+	req.Header.Set(EventIDHeader, delivery.EventID)
+	req.Header.Set(EventTypeHeader, string(delivery.EventType))
+
+	resp, err := d.client.Do(req)
+	attempt.Duration = time.Since(start)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	resp.Body.Close()
+
+	attempt.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("unexpected status %s", resp.Status)
+	}
+	return attempt
+}
+
+// backoff returns the wait after the given number of failed attempts.
+func (d *Dispatcher) backoff(failures int) time.Duration {
+	wait := d.initialBackoff
+	for i := 1; i < failures && wait < d.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.maxBackoff {
+		wait = d.maxBackoff
+	}
+	return wait
+}
+
+// eventType returns the type the outbox recorded the event under.
+func eventType(e *user.UserEvent) repository.EventType {
+	switch e.GetEvent().(type) {
+	case *user.UserEvent_UserCreated:
+		return repository.EventUserCreated
+	case *user.UserEvent_UserBlocked:
+		return repository.EventUserBlocked
+	case *user.UserEvent_UserUnblocked:
+		return repository.EventUserUnblocked
+	case *user.UserEvent_ContactChanged:
+		return repository.EventContactChanged
+	default:
+		return repository.EventUserUpdated
+	}
+}
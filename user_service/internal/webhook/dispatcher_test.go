@@ -0,0 +1,344 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+	"user_service/internal/repository"
+	"user_service/internal/repository/memory"
+	"user_service/protogen/user"
+)
+
+const testSecret = "0123456789abcdef"
+
+// receiver is an httptest server answering deliveries with the queued status
+// codes, then 200, and keeping the requests it got.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	t.Helper()
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+func createdEvent(id string) *user.UserEvent {
+	return &user.UserEvent{
+		EventId: id,
+		UserId:  "5b7f0c4e-3f8a-4d6e-9f61-1d2c3b4a5e6f",
+		Version: 1,
+		Event:   &user.UserEvent_UserCreated{UserCreated: &user.UserCreated{}},
+	}
+}
+
+// setup subscribes url to UserCreated events and publishes one.
+func setup(t *testing.T, url string, maxAttempts int) (*Dispatcher, *memory.WebhookStore) {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewWebhookStore()
+	for _, w := range []*repository.Webhook{
+		{ID: "created", URL: url, Secret: testSecret, EventTypes: []repository.EventType{repository.EventUserCreated}},
+		{ID: "blocked", URL: url, Secret: testSecret, EventTypes: []repository.EventType{repository.EventUserBlocked}},
+	} {
+		if err := store.CreateWebhook(ctx, w); err != nil {
+			t.Fatalf("CreateWebhook: %v", err)
+		}
+	}
+	d := NewDispatcher(store, nil, maxAttempts, time.Minute, time.Hour, 0)
+	if err := d.Publish(ctx, createdEvent("event-1")); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	return d, store
+}
+
+// delivery returns the only delivery of the "created" webhook.
+func delivery(t *testing.T, store *memory.WebhookStore) *repository.Delivery {
+	t.Helper()
+	deliveries, _, err := store.ListDeliveries(context.Background(), repository.DeliveryQuery{WebhookID: "created", PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListDeliveries: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+// makeDue moves the next attempt of the delivery to now, skipping the
+// backoff.
+func makeDue(t *testing.T, store *memory.WebhookStore, d *repository.Delivery) {
+	t.Helper()
+	d.NextAttemptAt = time.Now().UTC().Add(-time.Millisecond)
+	if err := store.UpdateDelivery(context.Background(), d); err != nil {
+		t.Fatalf("UpdateDelivery: %v", err)
+	}
+}
+
+func TestDeliverySignature(t *testing.T) {
+	r := newReceiver(t)
+	d, store := setup(t, r.URL, 3)
+
+	n, err := d.DeliverDue(context.Background(), 10)
+	if err != nil || n != 1 {
+		t.Fatalf("DeliverDue = %d, %v; want 1, nil", n, err)
+	}
+	got := r.received()
+	if len(got) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(got))
+	}
+	req := got[0]
+	if err := Verify(testSecret, req.header.Get(SignatureHeader), req.body, time.Minute, time.Now()); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := Verify("another secret!!", req.header.Get(SignatureHeader), req.body, time.Minute, time.Now()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify with wrong secret = %v, want ErrInvalidSignature", err)
+	}
+	if err := Verify(testSecret, req.header.Get(SignatureHeader), append(req.body, ' '), time.Minute, time.Now()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify of altered body = %v, want ErrInvalidSignature", err)
+	}
+	if err := Verify(testSecret, req.header.Get(SignatureHeader), req.body, time.Minute, time.Now().Add(time.Hour)); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("Verify an hour later = %v, want ErrSignatureExpired", err)
+	}
+	for header, want := range map[string]string{
+		WebhookIDHeader: "created",
+		EventIDHeader:   "event-1",
+		EventTypeHeader: string(repository.EventUserCreated),
+		"Content-Type":  "application/json",
+	} {
+		if got := req.header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	if got := delivery(t, store); got.Status != repository.DeliverySucceeded || len(got.Attempts) != 1 || got.Attempts[0].StatusCode != http.StatusOK {
+		t.Errorf("delivery = %+v, want one successful attempt", got)
+	}
+}
+
+func TestDeliveryRetry(t *testing.T) {
+	r := newReceiver(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	d, store := setup(t, r.URL, 5)
+	ctx := context.Background()
+
+	wantBackoff := []time.Duration{time.Minute, 2 * time.Minute}
+	for i, want := range wantBackoff {
+		if _, err := d.DeliverDue(ctx, 10); err != nil {
+			t.Fatalf("DeliverDue: %v", err)
+		}
+		got := delivery(t, store)
+		if got.Status != repository.DeliveryPending || len(got.Attempts) != i+1 {
+			t.Fatalf("after attempt %d: status %s with %d attempts, want PENDING with %d", i+1, got.Status, len(got.Attempts), i+1)
+		}
+		attempt := got.Attempts[i]
+		if attempt.Error == "" || attempt.StatusCode < 500 {
+			t.Errorf("attempt %d = %+v, want a recorded 5xx failure", i+1, attempt)
+		}
+		if wait := got.NextAttemptAt.Sub(attempt.AttemptedAt); wait != want {
+			t.Errorf("after attempt %d: next attempt in %s, want %s", i+1, wait, want)
+		}
+
+		// Not due yet: nothing is posted.
+		if n, err := d.DeliverDue(ctx, 10); err != nil || n != 0 {
+			t.Errorf("DeliverDue before backoff = %d, %v; want 0, nil", n, err)
+		}
+		makeDue(t, store, got)
+	}
+
+	if _, err := d.DeliverDue(ctx, 10); err != nil {
+		t.Fatalf("DeliverDue: %v", err)
+	}
+	if got := delivery(t, store); got.Status != repository.DeliverySucceeded || len(got.Attempts) != 3 {
+		t.Errorf("delivery = %s with %d attempts, want SUCCEEDED with 3", got.Status, len(got.Attempts))
+	}
+	if got := len(r.received()); got != 3 {
+		t.Errorf("receiver got %d requests, want 3", got)
+	}
+}
+
+func TestDeliverDueConcurrently(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	t.Cleanup(slow.Close)
+	fast := newReceiver(t)
+
+	store := memory.NewWebhookStore()
+	for _, w := range []*repository.Webhook{
+		{ID: "slow", URL: slow.URL, Secret: testSecret, EventTypes: []repository.EventType{repository.EventUserCreated}},
+		{ID: "fast", URL: fast.URL, Secret: testSecret, EventTypes: []repository.EventType{repository.EventUserCreated}},
+	} {
+		if err := store.CreateWebhook(ctx, w); err != nil {
+			t.Fatalf("CreateWebhook: %v", err)
+		}
+	}
+	d := NewDispatcher(store, nil, 5, time.Minute, time.Hour, 2)
+	for _, id := range []string{"event-1", "event-2", "event-3"} {
+		if err := d.Publish(ctx, createdEvent(id)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := d.DeliverDue(ctx, 10)
+		done <- result{n, err}
+	}()
+
+	// The fast webhook gets all its deliveries while the slow one is stuck
+	// on its first.
+	deadline := time.Now().Add(time.Second)
+	for len(fast.received()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := len(fast.received()); got != 3 {
+		t.Errorf("fast webhook got %d deliveries while the slow one was posting, want 3", got)
+	}
+	close(release)
+	if r := <-done; r.n != 6 || r.err != nil {
+		t.Errorf("DeliverDue = %d, %v; want 6, nil", r.n, r.err)
+	}
+	for i, want := range []string{"event-1", "event-2", "event-3"} {
+		if got := fast.received()[i].header.Get(EventIDHeader); got != want {
+			t.Errorf("delivery %d was event %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := NewDispatcher(memory.NewWebhookStore(), nil, 10, time.Second, 10*time.Second, 0)
+	for failures, want := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 8 * time.Second,
+		5: 10 * time.Second,
+		9: 10 * time.Second,
+	} {
+		if got := d.backoff(failures); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", failures, got, want)
+		}
+	}
+}
+
+func TestDeliveryDeadLetter(t *testing.T) {
+	t.Run("attempts exhausted", func(t *testing.T) {
+		r := newReceiver(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+		d, store := setup(t, r.URL, 3)
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			if _, err := d.DeliverDue(ctx, 10); err != nil {
+				t.Fatalf("DeliverDue: %v", err)
+			}
+			if got := delivery(t, store); got.Status == repository.DeliveryPending {
+				makeDue(t, store, got)
+			}
+		}
+		got := delivery(t, store)
+		if got.Status != repository.DeliveryDeadLetter || len(got.Attempts) != 3 || !got.NextAttemptAt.IsZero() {
+			t.Fatalf("delivery = %s with %d attempts, next at %s; want DEAD_LETTER with 3 and no next attempt",
+				got.Status, len(got.Attempts), got.NextAttemptAt)
+		}
+		if n, err := d.DeliverDue(ctx, 10); err != nil || n != 0 {
+			t.Errorf("DeliverDue after dead-lettering = %d, %v; want 0, nil", n, err)
+		}
+		if got := len(r.received()); got != 3 {
+			t.Errorf("receiver got %d requests, want 3", got)
+		}
+	})
+
+	t.Run("webhook deleted", func(t *testing.T) {
+		r := newReceiver(t)
+		d, store := setup(t, r.URL, 3)
+		ctx := context.Background()
+		if err := store.DeleteWebhook(ctx, "created"); err != nil {
+			t.Fatalf("DeleteWebhook: %v", err)
+		}
+
+		if _, err := d.DeliverDue(ctx, 10); err != nil {
+			t.Fatalf("DeliverDue: %v", err)
+		}
+		if got := delivery(t, store); got.Status != repository.DeliveryDeadLetter || len(got.Attempts) != 0 {
+			t.Errorf("delivery = %s with %d attempts, want DEAD_LETTER with none", got.Status, len(got.Attempts))
+		}
+		if got := len(r.received()); got != 0 {
+			t.Errorf("receiver got %d requests, want 0", got)
+		}
+	})
+}
+
+func TestNewClientRejectsInternalAddresses(t *testing.T) {
+	r := newReceiver(t)
+
+	_, err := NewClient(time.Second, false).Get(r.URL)
+	if !errors.Is(err, ErrDisallowedAddress) {
+		t.Errorf("GET %s = %v, want ErrDisallowedAddress", r.URL, err)
+	}
+	resp, err := NewClient(time.Second, true).Get(r.URL)
+	if err != nil {
+		t.Fatalf("GET %s with private networks allowed: %v", r.URL, err)
+	}
+	resp.Body.Close()
+}
+
+func TestCheckURL(t *testing.T) {
+	for _, url := range []string{
+		"http://127.0.0.1/hook",
+		"http://[::1]:8080/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"https://10.1.2.3/hook",
+		"https://192.168.0.10/hook",
+		"http://0.0.0.0/hook",
+		"http://100.64.0.1/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+	} {
+		if err := CheckURL(context.Background(), url, false); !errors.Is(err, ErrDisallowedAddress) {
+			t.Errorf("CheckURL(%s) = %v, want ErrDisallowedAddress", url, err)
+		}
+		if err := CheckURL(context.Background(), url, true); err != nil {
+			t.Errorf("CheckURL(%s) with private networks allowed = %v, want nil", url, err)
+		}
+	}
+	if err := CheckURL(context.Background(), "https://93.184.215.14/hook", false); err != nil {
+		t.Errorf("CheckURL of a public address = %v, want nil", err)
+	}
+}